@@ -0,0 +1,100 @@
+package binding
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// BatchResult reports the validation of a batch of records, like the rows of
+// a CSV or NDJSON import, per record, so an import UI can point at the rows
+// to fix.
+type BatchResult struct {
+	// Total is the number of records read, including those that failed to decode
+	Total int `json:"total"`
+
+	// Valid is the number of records without errors
+	Valid int `json:"valid"`
+
+	// Invalid is the number of records with errors
+	Invalid int `json:"invalid"`
+
+	// Records holds the errors of the invalid records, in the order of the batch
+	Records []RecordErrors `json:"records,omitempty"`
+}
+
+// RecordErrors are the errors of a single record of a batch, with the field
+// names relative to the record.
+type RecordErrors struct {
+	// Index is the position of the record in the batch, starting at 0
+	Index int `json:"index"`
+
+	Errors Errors `json:"errors"`
+}
+
+// BindBatch binds the records of the request into the slice obj points to
+// using the default binder, see Binder.BindBatch.
+func BindBatch(obj interface{}, req *http.Request) (*BatchResult, error) {
+	return defaultBinder.BindBatch(obj, req)
+}
+
+// BindBatch binds the records of a CSV or NDJSON request into the slice obj
+// points to, like Bind, and reports their validation errors grouped per record
+// in a BatchResult. Errors that are not about a record, like an unsupported
+// Content-Type, are returned as is.
+func (b *Binder) BindBatch(obj interface{}, req *http.Request) (*BatchResult, error) {
+	if req == nil {
+		return nil, ErrorNilRequest
+	}
+
+	stats, ok := req.Context().Value(statsContextKey{}).(*Stats)
+	if !ok || stats == nil {
+		stats = &Stats{}
+		req = WithStats(req, stats)
+	}
+	records := stats.Records
+
+	err := b.Bind(obj, req)
+	if _, ok := err.(Errors); err != nil && !ok {
+		return nil, err
+	}
+	return newBatchResult(stats.Records-records, toErrors(err))
+}
+
+// newBatchResult groups the errors of a batch of total records by the index
+// that starts their field names.
+func newBatchResult(total int, errors Errors) (*BatchResult, error) {
+	result := &BatchResult{Total: total}
+	positions := map[int]int{}
+	for _, err := range errors {
+		if len(err.FieldNames) == 0 {
+			return nil, errors
+		}
+		index, field, _ := strings.Cut(err.FieldNames[0], ".")
+		i, convErr := strconv.Atoi(index)
+		if convErr != nil {
+			return nil, errors
+		}
+
+		fieldNames := []string{}
+		if field != "" {
+			fieldNames = append(fieldNames, field)
+		}
+		for _, name := range err.FieldNames[1:] {
+			fieldNames = append(fieldNames, strings.TrimPrefix(name, index+"."))
+		}
+		err.FieldNames = fieldNames
+
+		position, exists := positions[i]
+		if !exists {
+			position = len(result.Records)
+			positions[i] = position
+			result.Records = append(result.Records, RecordErrors{Index: i})
+		}
+		result.Records[position].Errors = append(result.Records[position].Errors, err)
+	}
+
+	result.Invalid = len(result.Records)
+	result.Valid = result.Total - result.Invalid
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package binding
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type batchSuite struct{}
+
+var _ = Suite(&batchSuite{})
+
+func (s *batchSuite) Test_CSVBatch(c *C) {
+	contacts := []Contact{}
+	body := "full name,email\nMatt Holt,matt@example.com\n,invalid\n\"Michael,michael@example.com\n"
+	result, err := BindBatch(&contacts, newRequest(`POST`, ``, body, csvContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(result.Total, Equals, 3)
+	c.Assert(result.Valid, Equals, 1)
+	c.Assert(result.Invalid, Equals, 2)
+	c.Assert(result.Records, HasLen, 2)
+	c.Assert(result.Records[0], DeepEquals, RecordErrors{Index: 1, Errors: Errors{
+		{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Email"}, Classification: EmailError, Message: "Email"},
+	}})
+	c.Assert(result.Records[1].Index, Equals, 2)
+	c.Assert(result.Records[1].Errors[0].FieldNames, DeepEquals, []string{})
+	c.Assert(result.Records[1].Errors[0].Classification, Equals, DeserializationError)
+}
+
+func (s *batchSuite) Test_NDJSONBatch(c *C) {
+	people := []RequiredName{}
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n{}\n{\"Name\":\n{\"Name\": \"Michael Boke\"}\n", ndjsonContentType)
+	result, err := BindBatch(&people, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(result.Total, Equals, 4)
+	c.Assert(result.Valid, Equals, 2)
+	c.Assert(result.Invalid, Equals, 2)
+	c.Assert(result.Records, DeepEquals, []RecordErrors{
+		{Index: 1, Errors: Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}}},
+		{Index: 2, Errors: Errors{{FieldNames: []string{}, Classification: DeserializationError, Message: ErrorDeserialization.Error()}}},
+	})
+	c.Assert(people, HasLen, 2)
+}
+
+func (s *batchSuite) Test_AllValid(c *C) {
+	contacts := []Contact{}
+	result, err := BindBatch(&contacts, newRequest(`POST`, ``, "full name,email\nMatt Holt,matt@example.com\n", csvContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, &BatchResult{Total: 1, Valid: 1})
+}
+
+func (s *batchSuite) Test_NotARecordError(c *C) {
+	result, err := BindBatch(&[]Contact{}, newRequest(`POST`, ``, "full name\n", "application/unknown"))
+
+	c.Assert(err, Equals, ErrorUnsupportedContentType)
+	c.Assert(result, IsNil)
+}
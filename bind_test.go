@@ -1,6 +1,13 @@
 package binding
 
-import . "gopkg.in/check.v1"
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
 
 type bindSuite struct{}
 
@@ -26,6 +33,27 @@ func (s *bindSuite) Test_EmptyContentType(c *C) {
 	c.Assert(post, DeepEquals, Post{})
 }
 
+func (s *bindSuite) Test_DefaultContentType(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title", "content": "Lorem ipsum dolor sit amet"}`, ``)
+	binder := &Binder{DefaultContentType: MIMEJSON}
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
+}
+
+func (s *bindSuite) Test_DefaultContentTypeForm(c *C) {
+	post := Post{}
+	req := newRequest(`PUT`, `?content=Lorem+ipsum`, `title=Glorious+Post+Title`, ``)
+	binder := &Binder{DefaultContentType: MIMEPOSTForm}
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum"})
+	c.Assert(req.Header.Get("Content-Type"), Equals, "")
+}
+
 func (s *bindSuite) Test_Form(c *C) {
 	post := Post{}
 	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&content=Lorem+ipsum+dolor+sit+amet`, formContentType)
@@ -73,3 +101,275 @@ func (s *bindSuite) Test_Json(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
 }
+
+func (s *bindSuite) Test_Xml(c *C) {
+	for _, contentType := range []string{"application/xml", "text/xml; charset=utf-8"} {
+		post := Post{}
+		req := newRequest(`POST`, ``, `<Post><Title>Glorious Post Title</Title><Content>Lorem ipsum dolor sit amet</Content></Post>`, contentType)
+		err := Bind(&post, req)
+
+		c.Assert(err, IsNil)
+		c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
+	}
+}
+
+func (s *bindSuite) Test_XmlValidation(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `<RequiredName></RequiredName>`, "application/xml")
+	err := Bind(&person, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *bindSuite) Test_DryRun(c *C) {
+	post := Post{Title: "Original"}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&content=Lorem+ipsum+dolor+sit+amet`, formContentType)
+	result, err := DryRun(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, &Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
+	c.Assert(post, DeepEquals, Post{Title: "Original"})
+}
+
+func (s *bindSuite) Test_DryRunValidationErrors(c *C) {
+	sizes := Sizes{}
+	req := newRequest(`POST`, ``, `{"Country": "nld"}`, jsonContentType)
+	result, err := DryRun(&sizes, req)
+
+	c.Assert(err, NotNil)
+	c.Assert(err.(Errors).Has(LengthError), Equals, true)
+	c.Assert(result.(*Sizes).Country, Equals, "nld")
+	c.Assert(sizes, DeepEquals, Sizes{})
+}
+
+func (s *bindSuite) Test_DryRunKeepsLoadedValues(c *C) {
+	post := Post{Title: "Original", Content: "Loaded content"}
+	req := newRequest(`PATCH`, ``, `title=Glorious+Post+Title`, formContentType)
+	result, err := DryRun(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, &Post{Title: "Glorious Post Title", Content: "Loaded content"})
+	c.Assert(post, DeepEquals, Post{Title: "Original", Content: "Loaded content"})
+}
+
+type MailingAddress struct {
+	City string `form:"city"`
+}
+
+type Household struct {
+	Address *MailingAddress   `form:"addr"`
+	Members []*MailingAddress `form:"members"`
+	Labels  map[string]string `form:"labels"`
+}
+
+func (s *bindSuite) Test_DryRunCopiesNestedValues(c *C) {
+	household := Household{
+		Address: &MailingAddress{City: "Amsterdam"},
+		Members: []*MailingAddress{{City: "Utrecht"}},
+		Labels:  map[string]string{"kind": "home"},
+	}
+	req := newRequest(`PATCH`, `?addr.city=Berlin&members.0.city=Paris`, ``, formContentType)
+	result, err := DryRun(&household, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(result.(*Household).Address.City, Equals, "Berlin")
+	c.Assert(result.(*Household).Members[0].City, Equals, "Paris")
+	c.Assert(household.Address.City, Equals, "Amsterdam")
+	c.Assert(household.Members[0].City, Equals, "Utrecht")
+
+	result.(*Household).Labels["kind"] = "work"
+	c.Assert(household.Labels, DeepEquals, map[string]string{"kind": "home"})
+}
+
+func (s *bindSuite) Test_DryRunChecksStore(c *C) {
+	binder := &Binder{DBValidator: &memoryStore{rows: map[string]bool{"users.email=taken@example.com": true}}}
+	errs := binder.ValidateRequest(&SignUp{}, newRequest(`POST`, ``, `{"email": "taken@example.com"}`, jsonContentType))
+
+	c.Assert(errs, DeepEquals, Errors{{FieldNames: []string{"Email"}, Classification: UniqueError, Message: "Unique"}})
+}
+
+func (s *bindSuite) Test_DryRunHasNoSideEffects(c *C) {
+	store := &memoryNonceStore{used: map[string]bool{}}
+	failures := 0
+	binder := &Binder{
+		NonceStore: store,
+		OnFailure:  func(failure Failure) { failures++ },
+		OnShadow:   func(req *http.Request, violations Errors) { failures++ },
+		CsrfVerifier: func(req *http.Request, token string) bool {
+			failures++
+			return false
+		},
+	}
+	req := newRequest(`POST`, ``, `event=push&nonce=abc`, formContentType)
+	_, err := binder.DryRun(&SignedWebhook{}, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(store.used, HasLen, 0)
+
+	_, err = binder.DryRun(&SignedWebhook{}, newRequest(`POST`, ``, `event=%zz&nonce=abc`, formContentType))
+	c.Assert(err, NotNil)
+	c.Assert(failures, Equals, 0)
+
+	//the real submission still uses the nonce
+	err = binder.Bind(&SignedWebhook{}, newRequest(`POST`, ``, `event=push&nonce=abc`, formContentType))
+	c.Assert(err, IsNil)
+	c.Assert(store.used, HasLen, 1)
+}
+
+func (s *bindSuite) Test_DryRunNotByReference(c *C) {
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title`, formContentType)
+	result, err := DryRun(Post{}, req)
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+	c.Assert(result, IsNil)
+}
+
+func (s *bindSuite) Test_ValidateRequest(c *C) {
+	sizes := Sizes{}
+	req := newRequest(`POST`, ``, `{"Tags": {"a": "b"}, "Country": "nld", "Keywords": ["a", "b"]}`, jsonContentType)
+	errs := ValidateRequest(&sizes, req)
+
+	c.Assert(errs, DeepEquals, Errors{{FieldNames: []string{"Country"}, Classification: LengthError, Message: "Length"}})
+	c.Assert(sizes, DeepEquals, Sizes{})
+}
+
+func (s *bindSuite) Test_ValidateRequestValid(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	errs := ValidateRequest(&post, req)
+
+	c.Assert(errs, IsNil)
+}
+
+func (s *bindSuite) Test_ValidateRequestMalformed(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title":`, jsonContentType)
+	errs := ValidateRequest(&post, req)
+
+	c.Assert(errs, DeepEquals, Errors{{Classification: DeserializationError, Message: ErrorDeserialization.Error()}})
+}
+
+func (s *bindSuite) Test_StrictAPI(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title", "unknown": true}`, jsonContentType)
+	err := StrictAPI().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+
+	post = Post{}
+	req = newRequest(`POST`, `?content=Polluted`, `title=Glorious+Post+Title&title=Other`, formContentType)
+	err = StrictAPI().Bind(&post, req)
+
+	c.Assert(err, NotNil)
+	c.Assert(err.(Errors).Has(MultipleValuesError), Equals, true)
+}
+
+func (s *bindSuite) Test_StrictAPIContentTypes(c *C) {
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, "application/definitely-not-json")
+	err := StrictAPI().Bind(&Post{}, req)
+
+	c.Assert(err, Equals, ErrorUnsupportedContentType)
+
+	req = newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, "application/vnd.api+json")
+	err = StrictAPI().Bind(&Post{}, req)
+
+	c.Assert(err, IsNil)
+}
+
+func (s *bindSuite) Test_MaxRequestSizeCapsEveryBinding(c *C) {
+	for _, contentType := range []string{jsonContentType, "application/xml", formContentType} {
+		req := newRequest(`POST`, ``, strings.Repeat(" ", 64), contentType)
+		req.ContentLength = -1
+		binder := &Binder{MaxRequestSize: 16}
+		err := binder.Bind(&Post{}, req)
+
+		c.Assert(err, Equals, ErrorRequestTooLarge, Commentf("%s", contentType))
+	}
+}
+
+func (s *bindSuite) Test_LenientForms(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `?content=Lorem+ipsum+dolor+sit+amet`, `title=Glorious+Post+Title&title=Other&unknown=1`, formContentType)
+	err := LenientForms().Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
+}
+
+type unreadBody struct {
+	io.Reader
+	read bool
+}
+
+func (b *unreadBody) Read(p []byte) (int, error) {
+	b.read = true
+	return b.Reader.Read(p)
+}
+
+func (s *bindSuite) Test_CheckHeadersRejectsBeforeReadingBody(c *C) {
+	rejected := errors.New("uploads need a token")
+	binder := &Binder{CheckHeaders: func(req *http.Request) error {
+		if req.Header.Get("X-Upload-Token") == "" {
+			return rejected
+		}
+		return nil
+	}}
+
+	for contentType, model := range map[string]interface{}{
+		formContentType:                   &Post{},
+		jsonContentType:                   &Post{},
+		"multipart/form-data; boundary=x": &Post{},
+		"text/csv":                        &[]Post{},
+	} {
+		body := &unreadBody{Reader: strings.NewReader(`title=Glorious+Post+Title`)}
+		req := newRequest(`POST`, ``, ``, contentType)
+		req.Body = io.NopCloser(body)
+		req.Header.Set("Expect", "100-continue")
+		err := binder.Bind(model, req)
+
+		c.Assert(err, Equals, rejected)
+		c.Assert(body.read, Equals, false)
+	}
+}
+
+func (s *bindSuite) Test_MultipartContentLengthTooLarge(c *C) {
+	body := &unreadBody{Reader: strings.NewReader(`--x--`)}
+	req := newRequest(`POST`, ``, ``, "multipart/form-data; boundary=x")
+	req.Body = io.NopCloser(body)
+	req.ContentLength = 2048
+	binder := &Binder{MaxRequestSize: 1024}
+	err := binder.Bind(&Post{}, req)
+
+	c.Assert(err, Equals, ErrorRequestTooLarge)
+	c.Assert(body.read, Equals, false)
+}
+
+func (s *bindSuite) Test_NilRequest(c *C) {
+	for _, binding := range []Binding{Form, MultipartForm, JSON, JSONMergePatch, JSONPatch, XML, YAML, Text} {
+		err := binding.Bind(&Post{}, nil)
+
+		c.Assert(err, Equals, ErrorNilRequest, Commentf("%s", binding.Name()))
+	}
+
+	c.Assert(Bind(&Post{}, nil), Equals, ErrorNilRequest)
+	c.Assert(Query(&Post{}, nil), Equals, ErrorNilRequest)
+	c.Assert(NDJSON.Bind(&[]Post{}, nil), Equals, ErrorNilRequest)
+}
+
+func (s *bindSuite) Test_WithoutBody(c *C) {
+	contentTypes := map[Binding]string{
+		Form:          formContentType,
+		MultipartForm: "multipart/form-data; boundary=foo",
+		JSON:          jsonContentType,
+		XML:           "application/xml",
+	}
+	for binding, contentType := range contentTypes {
+		for _, body := range []string{"-nil-", ""} {
+			post := Post{Content: "stored"}
+			err := binding.Bind(&post, newRequest(`POST`, `/`, body, contentType))
+
+			c.Assert(err, IsNil, Commentf("%s %q", binding.Name(), body))
+			c.Assert(post, DeepEquals, Post{Content: "stored"})
+		}
+	}
+}
@@ -2,23 +2,37 @@ package binding
 
 import (
 	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	jsonContentType = "application/json; charset=utf-8"
 
-	MIMEJSON      = "application/json"
-	MIMEHTML      = "text/html"
-	MIMEXML       = "application/xml"
-	MIMEXML2      = "text/xml"
-	MIMEPlain     = "text/plain"
-	MIMEPOSTForm  = "application/x-www-form-urlencoded"
-	MIMEMultipart = "multipart/form-data"
+	MIMEJSON       = "application/json"
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+	MIMEGraphQL    = "application/graphql"
+	MIMEHTML       = "text/html"
+	MIMEXML        = "application/xml"
+	MIMEXML2       = "text/xml"
+	MIMEYAML       = "application/x-yaml"
+	MIMEYAML2      = "text/yaml"
+	MIMETOML       = "application/toml"
+	MIMEPROTOBUF   = "application/x-protobuf"
+	MIMEPROTOBUF2  = "application/protobuf"
+	MIMECBOR       = "application/cbor"
+	MIMENDJSON     = "application/x-ndjson"
+	MIMECSV        = "text/csv"
+	MIMEPlain      = "text/plain"
+	MIMEPOSTForm   = "application/x-www-form-urlencoded"
+	MIMEMultipart  = "multipart/form-data"
 )
 
 type Binding interface {
@@ -26,6 +40,441 @@ type Binding interface {
 	Bind(interface{}, *http.Request) error
 }
 
+// ParamExtractor returns the path parameters of a request by name, as parsed
+// by the router, for the fields tagged with uri, for example mux.Vars from
+// github.com/gorilla/mux.
+type ParamExtractor func(req *http.Request) map[string]string
+
+// UnknownPartPolicy decides what the multipart binding does with parts
+// that do not match any field of the bound struct.
+type UnknownPartPolicy int
+
+const (
+	// IgnoreUnknownParts drops unmatched parts silently (default)
+	IgnoreUnknownParts UnknownPartPolicy = iota
+
+	// RejectUnknownParts fails the binding with an UnknownPartError
+	RejectUnknownParts
+
+	// HandleUnknownParts passes every unmatched part to the UnknownPartHandler
+	HandleUnknownParts
+)
+
+// MultipleValuePolicy decides what happens when a key posted more than once
+// targets a field that holds a single value.
+type MultipleValuePolicy int
+
+const (
+	// UseFirstValue binds the first of the posted values (default)
+	UseFirstValue MultipleValuePolicy = iota
+
+	// RejectMultipleValues fails the binding with a MultipleValuesError
+	RejectMultipleValues
+)
+
+// PlusPolicy decides how a plus sign in a url encoded form value is decoded.
+type PlusPolicy int
+
+const (
+	// PlusAsSpace decodes a plus sign as a space in the body and query string (default)
+	PlusAsSpace PlusPolicy = iota
+
+	// LiteralPlusInQuery keeps a plus sign in the query string, the body still decodes it as a space
+	LiteralPlusInQuery
+
+	// LiteralPlus keeps a plus sign in the body and query string
+	LiteralPlus
+)
+
+// Binder holds the options used while binding requests. The zero value
+// is ready to use and behaves like the package level bindings.
+type Binder struct {
+	// MaxMemory is the amount of memory a multipart form may use before
+	// file parts spill to disk; zero uses the package level MaxMemory.
+	MaxMemory int64
+
+	// MaxRequestSize caps the size of the request body read by any of the
+	// bindings, once decompressed; zero means no limit. Larger bodies fail with
+	// ErrorRequestTooLarge.
+	MaxRequestSize int64
+
+	// Limits is the decoding budget enforced by Form, MultipartForm and JSON.
+	Limits Limits
+
+	// DisallowUnknownFields makes the JSON binding fail on object keys that do
+	// not match any field of the destination.
+	DisallowUnknownFields bool
+
+	// NoPointerAllocation disables the allocation of a nil pointer passed by
+	// reference (a nil **T); binding fails with ErrorInputIsNilPointer instead.
+	NoPointerAllocation bool
+
+	// KeepZeroEmbeds keeps an embedded struct pointer allocated when any of its
+	// fields were submitted, even if the bound result is the zero value. By default
+	// a zero valued embedded pointer is reset to nil. The same behaviour can be
+	// enabled per field with the `form:",keepzero"` tag option.
+	KeepZeroEmbeds bool
+
+	// SeparateFormSources stops merging the request body and query string values.
+	// Fields bind from the body of POST, PUT and PATCH requests and from the query
+	// string otherwise. A field can select its source explicitly with the body or
+	// query tag option, `form:"name,body"`, regardless of this setting.
+	SeparateFormSources bool
+
+	// MultipleValues sets the policy for single value fields that receive more
+	// than one value, which protects against HTTP parameter pollution.
+	MultipleValues MultipleValuePolicy
+
+	// TimeLocation is the location timestamps without a zone are interpreted in,
+	// unless the field names one with the time_location tag; nil means UTC.
+	TimeLocation *time.Location
+
+	// TimeUTC converts every bound time.Time value to UTC.
+	TimeUTC bool
+
+	// BinaryEncoding is the encoding of form values bound to []byte fields and
+	// fields implementing encoding.BinaryUnmarshaler, unless the field names
+	// one with the encoding tag: "base64" (the default), "base64url", "hex" or
+	// "base32". Base64 values are accepted with and without padding.
+	BinaryEncoding string
+
+	// Plus sets how the form binding decodes a plus sign in values.
+	Plus PlusPolicy
+
+	// RelaxedNumbers makes the form bindings accept digit separators, like
+	// 1_000_000, and scientific notation, like 1e6, in integer fields, as
+	// they are in float fields.
+	RelaxedNumbers bool
+
+	// KeyDecodeErrors makes the form binding decode every key and value on its
+	// own. Pairs that fail to decode are reported as a DeserializationError on
+	// their key while the other fields are still bound, instead of failing the
+	// whole binding with ErrorDeserialization.
+	KeyDecodeErrors bool
+
+	// PartialForm keeps the form binding going when the form fails to parse.
+	// Fields are bound from whatever was parsed, including the query string,
+	// and the parse error is returned as a DeserializationError together with
+	// any validation errors.
+	PartialForm bool
+
+	// ValidationWorkers is the number of goroutines that validate large slices
+	// of structs, like the rows of a batch import; zero or one validates them
+	// serially. The errors are reported in the same order either way.
+	ValidationWorkers int
+
+	// NonceStore checks the fields tagged with the Nonce rule once the bound
+	// struct passed validation; a nonce that was used before fails with a
+	// ReplayError. Nil disables the check.
+	NonceStore NonceStore
+
+	// NonceWindow is how far the fields tagged with the Timestamp rule, a
+	// time.Time or seconds since the Unix epoch, may lie from the time of the
+	// Clock in either direction. A timestamp outside of it fails with a
+	// ReplayError before any nonce is used. Zero disables the check.
+	NonceWindow time.Duration
+
+	// CsrfVerifier checks the token posted in the field tagged with `csrf:"true"`
+	// during Form and MultipartForm binding. A rejected token fails the binding
+	// with a CsrfError before validation. Nil disables the check.
+	CsrfVerifier func(req *http.Request, token string) bool
+
+	// CSVWithoutHeader makes the CSV binding treat the first row as data; the
+	// columns are then assigned to the fields of the struct in order.
+	CSVWithoutHeader bool
+
+	// YAMLUnmarshal decodes the YAML bodies for the YAML binding, for example
+	// yaml.Unmarshal from gopkg.in/yaml.v3. Nil disables YAML binding.
+	YAMLUnmarshal func(data []byte, v interface{}) error
+
+	// FlagSpam reports a filled in honeypot field, tagged with `honeypot:"true"`,
+	// as a SpamError warning in the Stats instead of failing the binding with a
+	// SpamError. Neither names the field that was filled in.
+	FlagSpam bool
+
+	// TOMLUnmarshal decodes the TOML bodies for the TOML binding, for example
+	// toml.Unmarshal from github.com/BurntSushi/toml. Nil disables TOML binding.
+	TOMLUnmarshal func(data []byte, v interface{}) error
+
+	// ProtobufUnmarshal decodes the protobuf bodies for the Protobuf binding,
+	// for example a function calling proto.Unmarshal from
+	// google.golang.org/protobuf/proto. Nil disables protobuf binding.
+	ProtobufUnmarshal func(data []byte, v interface{}) error
+
+	// CBORUnmarshal decodes the CBOR bodies for the CBOR binding, for example
+	// cbor.Unmarshal from github.com/fxamacker/cbor/v2. Nil disables CBOR binding.
+	CBORUnmarshal func(data []byte, v interface{}) error
+
+	// WireFieldNames names the fields in validation errors by their form
+	// tag, else their json tag, instead of by their Go field name, so the
+	// errors can be shown next to the inputs that were posted.
+	WireFieldNames bool
+
+	// RequiredFileErrors reports a Required file field, a *multipart.FileHeader,
+	// *File or a slice of those, that received no file part as a
+	// RequiredFileError named by its form key instead of as a RequiredError.
+	RequiredFileErrors bool
+
+	// Provenance records in the Source of each error which stage produced it:
+	// the binding, like "form" or "json", or the rule and field, like
+	// "rule:Required binding.Person.Name". Meant for debugging.
+	Provenance bool
+
+	// WarnUnbindable adds a warning with an UnbindableError to the Stats for
+	// every field the form bindings skip because it can never be bound: channels,
+	// functions, unsafe pointers and the types of the sync packages, like an
+	// embedded sync.Mutex.
+	WarnUnbindable bool
+
+	// Clock returns the current time for the Before(now) and After(now) rules
+	// and the Default(now) of time.Time fields, so time dependent validation
+	// can be tested. Nil uses time.Now.
+	Clock func() time.Time
+
+	// Random is the source of the identifiers generated by Default(uuid), so
+	// they can be made deterministic in tests. Nil uses crypto/rand.
+	Random io.Reader
+
+	// OnRule is called for every rule evaluated during validation, reporting
+	// whether it failed, for example to find rules that never fail. Rules
+	// skipped for a nil pointer or an empty value are not reported. It is
+	// called from multiple goroutines when ValidationWorkers is set.
+	OnRule func(usage RuleUsage)
+
+	// OnShadow receives the violations of the rules in the shadow tags,
+	// `shadow:"Required;MinSize(10)"`, of the bound struct. The shadow rules
+	// are evaluated next to the rules in the binding tags but never fail the
+	// binding, so stricter upcoming rules can be canaried on live traffic.
+	// The shadow rules are skipped when OnShadow is nil.
+	OnShadow func(req *http.Request, violations Errors)
+
+	// OnFailure is called when a binding fails with one of the
+	// FailureClassifications, for example to feed a rate limiter.
+	OnFailure func(failure Failure)
+
+	// OnWarnings is called with the warnings of a binding, the issues that
+	// did not fail it: values posted under a deprecated name, a honeypot
+	// flagged with FlagSpam and the violations of soft rules. The warnings
+	// are also added to the Stats of a request bound with WithStats; without
+	// either of the two they are dropped.
+	OnWarnings func(req *http.Request, warnings Errors)
+
+	// FailureClassifications are the classifications reported to OnFailure;
+	// nil reports oversized, malformed, spam, CSRF and replayed requests.
+	FailureClassifications []string
+
+	// StreamMultipart makes the MultipartForm binding walk the parts one by one
+	// instead of reading the whole form with ReadForm. File parts are handed
+	// to the UploadStore as they arrive and bind to *File and []*File fields
+	// only; without an UploadStore they are discarded.
+	StreamMultipart bool
+
+	// UploadStore stores the file parts in streaming mode. The files stored by
+	// a RemovableUploadStore are removed again when the binding fails.
+	UploadStore UploadStore
+
+	// CheckHeaders is called by every binding before the request body is read;
+	// returning an error fails the binding with that error without reading the
+	// body. Use it to reject uploads sent with Expect: 100-continue on their
+	// headers alone, before the client transmits the body.
+	CheckHeaders func(req *http.Request) error
+
+	// ParamExtractor reads the path parameters bound into the fields tagged
+	// with uri from the router. Nil uses Request.PathValue of the net/http
+	// ServeMux.
+	ParamExtractor ParamExtractor
+
+	// StrictContentTypes makes Bind select the binding by the exact media type
+	// of the Content-Type, or its structured syntax suffix like +json, only.
+	// Without it Bind falls back to guessing from a part of the content type,
+	// binding application/something-json as JSON; with it those requests fail
+	// with ErrorUnsupportedContentType.
+	StrictContentTypes bool
+
+	// DefaultContentType is the media type Bind assumes for POST, PUT and PATCH
+	// requests without a Content-Type header, instead of failing with
+	// ErrorEmptyContentType. The header of the request is left untouched.
+	DefaultContentType string
+
+	// MaxDecompressedSize caps the size of a request body sent with a gzip,
+	// deflate or other Content-Encoding once decompressed; zero means no limit.
+	// Larger bodies fail with ErrorRequestTooLarge.
+	MaxDecompressedSize int64
+
+	// Decompressors adds support for more Content-Encodings, for example "br",
+	// keyed by the lowercase encoding name.
+	Decompressors map[string]func(r io.Reader) (io.ReadCloser, error)
+
+	// VerifyDigest checks the request body against its Digest header, using
+	// the strongest of sha-512, sha-256, sha and md5 it lists, or against its
+	// Content-MD5 header. The body is hashed while it is bound and read to the
+	// end when the binding did not need all of it; a mismatch fails the binding
+	// with ErrorIntegrity.
+	VerifyDigest bool
+
+	// VerifyContentLength compares the number of bytes in the request body
+	// with its Content-Length header, reading the body to the end when the
+	// binding did not need all of it. A difference of more than
+	// ContentLengthTolerance bytes fails the binding with ErrorContentLength.
+	// The bytes read are counted in Stats.BytesRead.
+	VerifyContentLength bool
+
+	// ContentLengthTolerance is the difference VerifyContentLength allows.
+	ContentLengthTolerance int64
+
+	// RequireContentLength fails the binding of POST, PUT and PATCH requests
+	// that are not chunked and have no Content-Length header with
+	// ErrorLengthRequired.
+	RequireContentLength bool
+
+	// UnknownParts sets the policy for multipart parts without a matching field.
+	UnknownParts UnknownPartPolicy
+
+	// UnknownPartHandler is called for each unmatched multipart part when
+	// UnknownParts is set to HandleUnknownParts. Returning an error aborts the binding.
+	UnknownPartHandler func(name string, values []string, files []*multipart.FileHeader) error
+
+	// DBValidator runs the Unique and Exists rules against the store of the
+	// application, once the other rules pass. Without one they are ignored.
+	DBValidator DBValidator
+
+	// ContextValues adds the value each provider returns for the request to
+	// the context of the custom rules, under its key, like the plan of the
+	// tenant for a MaxSizeByPlan rule.
+	ContextValues map[interface{}]ValueProvider
+
+	// MaxRecordErrors stops ImportRecords once that many records failed to
+	// decode or validate; zero means no limit.
+	MaxRecordErrors int
+
+	// skipValidation leaves the validation to the caller, for binding the
+	// field tagged with `in:"body"`
+	skipValidation bool
+
+	// dryRun skips the side effects of a binding: nonces are not used, the
+	// CsrfVerifier, OnShadow, OnFailure and OnWarnings hooks are not called
+	// and streamed file parts are discarded instead of stored
+	dryRun bool
+
+	// mediaTypes are the bindings registered with RegisterMediaType
+	mediaTypes map[string]Binding
+}
+
+var defaultBinder = &Binder{}
+
+// StrictAPI returns a binder for APIs that should reject anything unexpected:
+// unknown JSON fields and multipart parts, repeated single value keys, query
+// string values mixed into request bodies and content types that are not
+// known exactly, with bounded request sizes.
+func StrictAPI() *Binder {
+	return &Binder{
+		MaxRequestSize:     10 * 1024 * 1024,
+		StrictContentTypes: true,
+		Limits: Limits{
+			MaxKeys:        1000,
+			MaxValueLength: 1024 * 1024,
+			MaxDepth:       32,
+		},
+		DisallowUnknownFields: true,
+		SeparateFormSources:   true,
+		MultipleValues:        RejectMultipleValues,
+		UnknownParts:          RejectUnknownParts,
+	}
+}
+
+// LenientForms returns a binder for HTML form applications, which ignores
+// anything it cannot map and keeps submitted embedded structs, while
+// still capping the size of uploads.
+func LenientForms() *Binder {
+	return &Binder{
+		MaxRequestSize: 32 * 1024 * 1024,
+		KeepZeroEmbeds: true,
+		MultipleValues: UseFirstValue,
+		UnknownParts:   IgnoreUnknownParts,
+	}
+}
+
+// binderOrDefault returns b, or the default binder when b is nil.
+func binderOrDefault(b *Binder) *Binder {
+	if b == nil {
+		return defaultBinder
+	}
+	return b
+}
+
+// maxMemory returns the multipart memory threshold of this binder.
+func (b *Binder) maxMemory() int64 {
+	if b.MaxMemory > 0 {
+		return b.MaxMemory
+	}
+	return MaxMemory
+}
+
+// Form returns the form binding using the options of this binder.
+func (b *Binder) Form() Binding {
+	return formBinding{binder: b}
+}
+
+// MultipartForm returns the multipart form binding using the options of this binder.
+func (b *Binder) MultipartForm() Binding {
+	return multipartBinding{binder: b}
+}
+
+// JSON returns the json binding using the options of this binder.
+func (b *Binder) JSON() Binding {
+	return jsonBinding{binder: b}
+}
+
+// JSONMergePatch returns the json merge patch binding using the options of this binder.
+func (b *Binder) JSONMergePatch() Binding {
+	return jsonMergePatchBinding{binder: b}
+}
+
+// JSONPatch returns the json patch binding using the options of this binder.
+func (b *Binder) JSONPatch() Binding {
+	return jsonPatchBinding{binder: b}
+}
+
+// XML returns the xml binding using the options of this binder.
+func (b *Binder) XML() Binding {
+	return xmlBinding{binder: b}
+}
+
+// YAML returns the yaml binding using the options of this binder.
+func (b *Binder) YAML() Binding {
+	return yamlBinding{binder: b}
+}
+
+// TOML returns the toml binding using the options of this binder.
+func (b *Binder) TOML() Binding {
+	return tomlBinding{binder: b}
+}
+
+// Protobuf returns the protobuf binding using the options of this binder.
+func (b *Binder) Protobuf() Binding {
+	return protobufBinding{binder: b}
+}
+
+// NDJSON returns the newline delimited json binding using the options of this binder.
+func (b *Binder) NDJSON() Binding {
+	return ndjsonBinding{binder: b}
+}
+
+// CSV returns the csv binding using the options of this binder.
+func (b *Binder) CSV() Binding {
+	return csvBinding{binder: b}
+}
+
+// Text returns the raw text binding using the options of this binder.
+func (b *Binder) Text() Binding {
+	return textBinding{binder: b}
+}
+
+// CBOR returns the cbor binding using the options of this binder.
+func (b *Binder) CBOR() Binding {
+	return cborBinding{binder: b}
+}
+
 var (
 	// Maximum amount of memory to use when parsing a multipart form.
 	// Set this to whatever value you prefer; default is 16 MB.
@@ -36,11 +485,31 @@ var (
 	ErrorUnsupportedContentType = errors.New("Unsupported Content-Type")
 	ErrorInputNotByReference    = errors.New("input binding model is not by reference")
 	ErrorInputIsNotStructure    = errors.New("binding model is required to be structure")
+	ErrorInputIsNilPointer      = errors.New("binding model is a nil pointer")
+	ErrorInputIsNotSlice        = errors.New("binding model is required to be a slice")
+	ErrorRequestTooLarge        = errors.New("Request too large")
+	ErrorUnsupportedVersion     = errors.New("Unsupported model version")
+	ErrorIntegrity              = errors.New("Integrity check failed")
+	ErrorContentLength          = errors.New("Content-Length mismatch")
+	ErrorLengthRequired         = errors.New("Content-Length required")
+	ErrorNilRequest             = errors.New("Nil request")
+	ErrorNilResponse            = errors.New("Nil response")
+	ErrorTooManyInvalidRecords  = errors.New("Too many invalid records")
+	ErrorStore                  = errors.New("Store error")
 
-	JSON          = jsonBinding{}
-	XML           = xmlBinding{}
-	Form          = formBinding{}
-	MultipartForm = multipartBinding{}
+	JSON           = jsonBinding{}
+	JSONMergePatch = jsonMergePatchBinding{}
+	JSONPatch      = jsonPatchBinding{}
+	XML            = xmlBinding{}
+	YAML           = yamlBinding{}
+	TOML           = tomlBinding{}
+	Protobuf       = protobufBinding{}
+	CBOR           = cborBinding{}
+	NDJSON         = ndjsonBinding{}
+	CSV            = csvBinding{}
+	Text           = textBinding{}
+	Form           = formBinding{}
+	MultipartForm  = multipartBinding{}
 )
 
 func Default(method, contentType string) Binding {
@@ -52,6 +521,10 @@ func Default(method, contentType string) Binding {
 			return Form
 		case MIMEJSON:
 			return JSON
+		case MIMEMergePatch:
+			return JSONMergePatch
+		case MIMEJSONPatch:
+			return JSONPatch
 		case MIMEXML, MIMEXML2:
 			return XML
 		default:
@@ -67,14 +540,85 @@ func Default(method, contentType string) Binding {
 }
 
 func Bind(obj interface{}, req *http.Request) error {
+	return defaultBinder.Bind(obj, req)
+}
+
+// Bind selects the binding by the Content-Type of the request, like the
+// package level Bind, and binds obj using the options of this binder. The
+// fields tagged with uri, query, header or cookie, or with the matching in
+// tag, are bound from the request first. When obj has a field tagged with
+// `in:"body"` the body is bound into that field only, and obj is validated as
+// a whole once every source is bound.
+func (b *Binder) Bind(obj interface{}, req *http.Request) error {
+	if req == nil {
+		return ErrorNilRequest
+	}
+	if errors := b.bindParameters(reflect.ValueOf(obj), req); len(errors) > 0 {
+		return errors
+	}
+
+	if body, ok := bodyField(reflect.ValueOf(obj)); ok {
+		bodyBinder := *b
+		bodyBinder.skipValidation = true
+		if err := bodyBinder.bindBody(body.Addr().Interface(), req); err != nil {
+			return err
+		}
+		return b.validate(obj, req)
+	}
+	return b.bindBody(obj, req)
+}
+
+// bindBody binds the body of the request into obj with the binding selected
+// by the Content-Type.
+func (b *Binder) bindBody(obj interface{}, req *http.Request) error {
 	contentType := req.Header.Get("Content-Type")
-	if req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH" || contentType != "" {
+	hasBody := req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH"
+	if contentType == "" && hasBody && b.DefaultContentType != "" {
+		contentType = b.DefaultContentType
+		req = withContentType(req, contentType)
+	}
+
+	if binding, ok := b.mediaTypeBinding(contentType); ok {
+		return binding.Bind(obj, req)
+	}
+
+	if hasBody || contentType != "" {
+		if binding, ok := b.structuredBinding(contentType); ok {
+			return binding.Bind(obj, req)
+		}
+		if b.StrictContentTypes {
+			if contentType == "" {
+				return ErrorEmptyContentType
+			}
+			return ErrorUnsupportedContentType
+		}
+
 		if strings.Contains(contentType, "form-urlencoded") {
-			return Form.Bind(obj, req)
+			return b.Form().Bind(obj, req)
 		} else if strings.Contains(contentType, "multipart/form-data") {
-			return MultipartForm.Bind(obj, req)
+			return b.MultipartForm().Bind(obj, req)
+		} else if strings.Contains(contentType, "merge-patch+json") {
+			return b.JSONMergePatch().Bind(obj, req)
+		} else if strings.Contains(contentType, "json-patch+json") {
+			return b.JSONPatch().Bind(obj, req)
+		} else if strings.Contains(contentType, "ndjson") {
+			return b.NDJSON().Bind(obj, req)
 		} else if strings.Contains(contentType, "json") {
-			return JSON.Bind(obj, req)
+			return b.JSON().Bind(obj, req)
+		} else if strings.Contains(contentType, "cbor") {
+			return b.CBOR().Bind(obj, req)
+		} else if strings.Contains(contentType, "toml") {
+			return b.TOML().Bind(obj, req)
+		} else if strings.Contains(contentType, "protobuf") {
+			return b.Protobuf().Bind(obj, req)
+		} else if strings.Contains(contentType, "yaml") {
+			return b.YAML().Bind(obj, req)
+		} else if strings.Contains(contentType, "csv") {
+			return b.CSV().Bind(obj, req)
+		} else if strings.Contains(contentType, "xml") {
+			return b.XML().Bind(obj, req)
+		} else if strings.Contains(contentType, "text/plain") || (contentType != "" && hasRawBodyField(obj)) {
+			return b.Text().Bind(obj, req)
 		} else {
 			if contentType == "" {
 				return ErrorEmptyContentType
@@ -83,183 +627,81 @@ func Bind(obj interface{}, req *http.Request) error {
 			}
 		}
 	} else {
-		return Form.Bind(obj, req)
+		return b.Form().Bind(obj, req)
 	}
 }
 
-/*
-var (
-	alphaDashPattern    = regexp.MustCompile("[^\\d\\w-_]")
-	alphaDashDotPattern = regexp.MustCompile("[^\\d\\w-_\\.]")
-	emailPattern        = regexp.MustCompile("[\\w!#$%&'*+/=?^_`{|}~-]+(?:\\.[\\w!#$%&'*+/=?^_`{|}~-]+)*@(?:[\\w](?:[\\w-]*[\\w])?\\.)+[a-zA-Z0-9](?:[\\w-]*[\\w])?")
-	urlPattern          = regexp.MustCompile(`(http|https):\/\/[\w\-_]+(\.[\w\-_]+)+([\w\-\.,@?^=%&amp;:/~\+#]*[\w\-\@?^=%&amp;/~\+#])?`)
-)
-*/
-/*
-// Performs required field checking on a struct
-func validateStruct(errors Errors, obj interface{}, path string) Errors {
-	typ := reflect.TypeOf(obj)
-	val := reflect.ValueOf(obj)
+// DryRun binds the request into a copy of the value obj points to, using the
+// default binder, and returns that copy with the binding error.
+func DryRun(obj interface{}, req *http.Request) (interface{}, error) {
+	return defaultBinder.DryRun(obj, req)
+}
 
-	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
-		val = val.Elem()
+// DryRun binds and validates the request into a deep copy of the value obj
+// points to and returns a pointer to that copy, so callers can inspect what
+// would have been set and which rules fail, keeping the values loaded into
+// obj for a PATCH. obj and the structs, slices and maps it refers to are not
+// modified. Nothing is persisted: nonces are not used, the CsrfVerifier,
+// OnShadow, OnFailure and OnWarnings hooks are not called and streamed file
+// parts are discarded instead of handed to the UploadStore. The read only
+// Unique and Exists lookups of the DBValidator do run.
+func (b *Binder) DryRun(obj interface{}, req *http.Request) (interface{}, error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr {
+		return nil, ErrorInputNotByReference
 	}
 
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-
-		// Allow ignored fields in the struct
-		if field.Tag.Get("form") == "-" || !val.Field(i).CanInterface() {
-			continue
-		}
+	dst := reflect.New(v.Type().Elem())
+	if !v.IsNil() {
+		dst.Elem().Set(deepCopy(v.Elem(), map[uintptr]reflect.Value{}))
+	}
+	dryRun := *b
+	dryRun.dryRun = true
+	err := dryRun.Bind(dst.Interface(), req)
+	return dst.Interface(), err
+}
 
-		fieldVal := val.Field(i)
-		fieldValue := fieldVal.Interface()
-		zero := reflect.Zero(field.Type).Interface()
+// ValidateRequest binds the request into a throwaway value of the type model
+// points to and returns the complete error report, meant for endpoints that
+// only preview validation. Errors that are not validation errors, like a
+// malformed body, are reported as a single classified entry.
+func ValidateRequest(model interface{}, req *http.Request) Errors {
+	return defaultBinder.ValidateRequest(model, req)
+}
 
-		// Validate nested and embedded structs (if pointer, only do so if not nil)
-		if field.Type.Kind() == reflect.Struct ||
-			(field.Type.Kind() == reflect.Ptr && !reflect.DeepEqual(zero, fieldValue) &&
-				field.Type.Elem().Kind() == reflect.Struct) {
-			fieldPath := path
-			if field.Anonymous == false {
-				fieldPath = path + field.Name + "."
-			}
-			errors = validateStruct(errors, fieldValue, fieldPath)
-			// Validate structure slices
-		} else if field.Type.Kind() == reflect.Slice &&
-			(field.Type.Elem().Kind() == reflect.Struct ||
-				(field.Type.Elem().Kind() == reflect.Ptr && field.Type.Elem().Elem().Kind() == reflect.Struct)) {
-			for i := 0; i < fieldVal.Len(); i++ {
-				fieldPath := path + field.Name + "." + strconv.Itoa(i) + "."
-				errors = validateStruct(errors, fieldVal.Index(i).Interface(), fieldPath)
-			}
-		}
+// ValidateRequest works like the package level ValidateRequest using the
+// options of this binder.
+func (b *Binder) ValidateRequest(model interface{}, req *http.Request) Errors {
+	_, err := b.DryRun(model, req)
+	return toErrors(err)
+}
 
-		// Match rules.
-	VALIDATE_RULES:
-		for _, rule := range strings.Split(field.Tag.Get("validate"), ";") {
-			if len(rule) == 0 {
-				continue
-			}
+// handleUnknownParts applies the unknown part policy to the multipart keys
+// the mapper did not bind.
+func (b *Binder) handleUnknownParts(m *formMapper) error {
+	if b.UnknownParts == IgnoreUnknownParts {
+		return nil
+	}
 
-			switch {
-			case rule == "Required":
-				if reflect.DeepEqual(zero, fieldValue) {
-					errors.Add([]string{path + field.Name}, RequiredError, "Required")
-					break
-				}
-			case rule == "AlphaDash":
-				if alphaDashPattern.MatchString(fmt.Sprintf("%v", fieldValue)) {
-					errors.Add([]string{path + field.Name}, AlphaDashError, "AlphaDash")
-					break VALIDATE_RULES
-				}
-			case rule == "AlphaDashDot":
-				if alphaDashDotPattern.MatchString(fmt.Sprintf("%v", fieldValue)) {
-					errors.Add([]string{path + field.Name}, AlphaDashDotError, "AlphaDashDot")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "MinSize("):
-				min, _ := strconv.Atoi(rule[8 : len(rule)-1])
-				if str, ok := fieldValue.(string); ok && utf8.RuneCountInString(str) < min {
-					errors.Add([]string{path + field.Name}, MinSizeError, "MinSize")
-					break VALIDATE_RULES
-				}
-				v := reflect.ValueOf(fieldValue)
-				if v.Kind() == reflect.Slice && v.Len() < min {
-					errors.Add([]string{path + field.Name}, MinSizeError, "MinSize")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "MaxSize("):
-				max, _ := strconv.Atoi(rule[8 : len(rule)-1])
-				if str, ok := fieldValue.(string); ok && utf8.RuneCountInString(str) > max {
-					errors.Add([]string{path + field.Name}, MaxSizeError, "MaxSize")
-					break VALIDATE_RULES
-				}
-				v := reflect.ValueOf(fieldValue)
-				if v.Kind() == reflect.Slice && v.Len() > max {
-					errors.Add([]string{path + field.Name}, MaxSizeError, "MaxSize")
-					break VALIDATE_RULES
-				}
-			case rule == "Email":
-				if !emailPattern.MatchString(fmt.Sprintf("%v", fieldValue)) {
-					errors.Add([]string{path + field.Name}, EmailError, "Email")
-					break VALIDATE_RULES
-				}
-			case rule == "Url":
-				str := fmt.Sprintf("%v", fieldValue)
-				if len(str) == 0 {
-					continue
-				} else if !urlPattern.MatchString(str) {
-					errors.Add([]string{path + field.Name}, UrlError, "Url")
-					break VALIDATE_RULES
-				}
+	unknown := m.unknownKeys()
+	if len(unknown) == 0 {
+		return nil
+	}
 
-			// TODO write test for these validation rules
-			case strings.HasPrefix(rule, "Range("):
-				nums := strings.Split(rule[6:len(rule)-1], ",")
-				if len(nums) != 2 {
-					break
-				}
-				val, _ := strconv.ParseInt(fmt.Sprintf("%v", fieldValue), 10, 32)
-				a, _ := strconv.ParseInt(nums[0], 10, 32)
-				b, _ := strconv.ParseInt(nums[1], 10, 32)
-				if val < a || val > b {
-					errors.Add([]string{path + field.Name}, RangeError, "Range")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "In("):
-				if !in(fieldValue, rule[3:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, InError, "In")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "NotIn("):
-				if in(fieldValue, rule[6:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, NotInError, "NotIn")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "Include("):
-				if !strings.Contains(fmt.Sprintf("%v", fieldValue), rule[8:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, IncludeError, "Include")
-					break VALIDATE_RULES
-				}
-			case strings.HasPrefix(rule, "Exclude("):
-				if strings.Contains(fmt.Sprintf("%v", fieldValue), rule[8:len(rule)-1]) {
-					errors.Add([]string{path + field.Name}, ExcludeError, "Exclude")
-					break
-				}
-			case strings.HasPrefix(rule, "Default("):
-				if reflect.DeepEqual(zero, fieldValue) {
-					if fieldVal.CanAddr() {
-						setWithProperType(field.Type.Kind(), rule[8:len(rule)-1], fieldVal, field.Tag.Get("form"), errors)
-					} else {
-						errors.Add([]string{path + field.Name}, DefaultError, "Default")
-						break VALIDATE_RULES
-					}
-				}
+	if b.UnknownParts == RejectUnknownParts {
+		return Errors{Error{FieldNames: unknown, Classification: UnknownPartError, Message: "Unknown part"}}
+	}
 
+	if b.UnknownPartHandler != nil {
+		for _, name := range unknown {
+			if err := b.UnknownPartHandler(name, m.form[name], m.formfile[name]); err != nil {
+				return err
 			}
 		}
 	}
-	return errors
-}
-*/
-//validation in function
-/*
-func in(fieldValue interface{}, arr string) bool {
-	val := fmt.Sprintf("%v", fieldValue)
-	vals := strings.Split(arr, ",")
-	isIn := false
-	for _, v := range vals {
-		if v == val {
-			isIn = true
-			break
-		}
-	}
-	return isIn
+	return nil
 }
-*/
+
 /*
 func mapFormValues(field string, form map[string][]string) (result []map[string][]string) {
 	for key, values := range form {
@@ -285,28 +727,430 @@ func mapFormValues(field string, form map[string][]string) (result []map[string]
 	return result
 }*/
 
+// tagOptions holds the comma separated options following the name in a struct tag.
+type tagOptions []string
+
+// parseTag splits a struct tag into its name and options.
+func parseTag(tag string) (string, tagOptions) {
+	parts := strings.Split(tag, ",")
+	return parts[0], tagOptions(parts[1:])
+}
+
+// Has reports whether option is set.
+func (o tagOptions) Has(option string) bool {
+	for _, opt := range o {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHeaders runs the checks that only need the request headers, before
+// any of the body is read. The server answers an Expect: 100-continue request
+// when the body is first read, so a client is not asked to send the body of a
+// request that fails them.
+func (b *Binder) checkHeaders(req *http.Request) error {
+	if b.CheckHeaders != nil {
+		return b.CheckHeaders(req)
+	}
+	return nil
+}
+
+// withContentType returns a shallow copy of req with contentType in a copy of
+// its header, for the bindings that parse the body by the header of the
+// request, without changing the request of the caller.
+func withContentType(req *http.Request, contentType string) *http.Request {
+	typed := *req
+	typed.Header = req.Header.Clone()
+	if typed.Header == nil {
+		typed.Header = http.Header{}
+	}
+	typed.Header.Set("Content-Type", contentType)
+	return &typed
+}
+
+// readError classifies an error returned while reading a request body.
+func readError(err error) error {
+	var limitErrors Errors
+	if errors.As(err, &limitErrors) {
+		return limitErrors
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || errors.Is(err, multipart.ErrMessageTooLarge) {
+		return ErrorRequestTooLarge
+	}
+	if errors.Is(err, ErrorIntegrity) {
+		return ErrorIntegrity
+	}
+	if errors.Is(err, ErrorContentLength) {
+		return ErrorContentLength
+	}
+	return ErrorDeserialization
+}
+
+// unmarshalBody reads the request body and decodes it into dst with
+// unmarshal, then validates dst. Bindings for formats this package has no
+// decoder for use it with the unmarshal function configured on the binder;
+// without one they fail with ErrorUnsupportedContentType.
+func (b *Binder) unmarshalBody(dst interface{}, req *http.Request, name string, unmarshal func([]byte, interface{}) error) (err error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = b.done(dst, req, name, err) }()
+
+	if unmarshal == nil {
+		return ErrorUnsupportedContentType
+	}
+	if b.NoPointerAllocation && isNilPointerReference(v) {
+		return ErrorInputIsNilPointer
+	}
+
+	if err := b.checkHeaders(req); err != nil {
+		return err
+	}
+	if req.Body != nil {
+		countBody(req, requestStats(req))
+		if err := b.decodeBody(req); err != nil {
+			return err
+		}
+		defer req.Body.Close()
+
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return readError(err)
+		}
+		if len(data) > 0 {
+			if err := unmarshal(data, dst); err != nil {
+				return ErrorDeserialization
+			}
+		}
+	}
+	return b.validate(dst, req)
+}
+
+// isUnbindable reports whether values of the type can never be bound or
+// validated: channels, functions, unsafe pointers and the types of the sync
+// and sync/atomic packages, or pointers to them.
+func isUnbindable(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	}
+	return typ.PkgPath() == "sync" || typ.PkgPath() == "sync/atomic"
+}
+
+// isNilPointerReference reports whether v is a reference to a nil pointer (a nil **T).
+func isNilPointerReference(v reflect.Value) bool {
+	return !v.IsNil() && v.Elem().Kind() == reflect.Ptr && v.Elem().IsNil()
+}
+
+// pathSliceSize returns the length of the slice of structs at the path of
+// field, like cart.items, from the highest index in the keys of its elements,
+// like cart.items.1.sku.
 func pathSliceSize(field string, form map[string][]string) int {
 	size := 0
-	for key, _ := range form {
-		parts := strings.SplitN(key, ".", 3)
-		if len(parts) == 3 && parts[0] == field {
-			index, err := strconv.Atoi(parts[1])
-			if err != nil {
-				continue
-			}
+	for key := range form {
+		if !strings.HasPrefix(key, field+".") {
+			continue
+		}
+		index, rest, found := strings.Cut(key[len(field)+1:], ".")
+		if !found || rest == "" {
+			continue
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			continue
+		}
 
-			if size < index+1 {
-				size = index + 1
-			}
+		if size < i+1 {
+			size = i + 1
 		}
 	}
 	return size
 }
 
-var fhType = reflect.TypeOf((*multipart.FileHeader)(nil))
+var (
+	fhType         = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fhSliceType    = reflect.TypeOf([]*multipart.FileHeader(nil))
+	fhMapType      = reflect.TypeOf(map[string]*multipart.FileHeader(nil))
+	fhSliceMapType = reflect.TypeOf(map[string][]*multipart.FileHeader(nil))
+)
+
+// formMapper holds the state of mapping a single set of form values
+// and files into a struct.
+type formMapper struct {
+	binder   *Binder
+	form     map[string][]string
+	formfile map[string][]*multipart.FileHeader
+
+	// body and query hold the values per origin, for fields that select
+	// their source with the body or query tag option
+	body  map[string][]string
+	query map[string][]string
+
+	// defaults are the values bound into fields without a source option
+	defaults map[string][]string
+
+	// used records every form and file key that was bound to a field
+	used map[string]bool
+
+	// bound and skipped count the fields that did and did not receive a value
+	bound   int
+	skipped int
+
+	// fileCatchAlls are the file map fields that receive the unclaimed files
+	fileCatchAlls []reflect.Value
+
+	// warnings are the non fatal issues found while mapping
+	warnings Errors
+
+	// aliases maps the keys of fields to the deprecated name that supplied their value
+	aliases map[string]string
+
+	// csrfTokens are the values posted for the fields tagged as CSRF token
+	csrfTokens []csrfToken
+
+	// storedFiles are the files handed to the UploadStore in streaming mode
+	storedFiles map[string][]*File
+}
+
+func newFormMapper(binder *Binder, form map[string][]string, formfile map[string][]*multipart.FileHeader) *formMapper {
+	form = normalizeFormValues(form)
+	formfile = normalizeFormFiles(formfile)
+	return &formMapper{
+		binder:   binderOrDefault(binder),
+		form:     form,
+		formfile: formfile,
+		body:     form,
+		defaults: form,
+		used:     make(map[string]bool),
+		aliases:  make(map[string]string),
+	}
+}
+
+// withSources sets the values of the request body and query string. When the
+// binder separates the sources, fields without a source option only bind from
+// the body of requests that have one, and from the query string otherwise.
+func (m *formMapper) withSources(req *http.Request, body map[string][]string) *formMapper {
+	m.body = normalizeFormValues(body)
+	m.query = normalizeFormValues(req.URL.Query())
+	if m.binder.SeparateFormSources {
+		if req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH" {
+			m.defaults = m.body
+		} else {
+			m.defaults = m.query
+		}
+	}
+	return m
+}
+
+// deprecatedValues looks up the values posted under the deprecated names of a
+// field and records a warning when one of them is used.
+func (m *formMapper) deprecatedValues(path, name, deprecated string, options tagOptions) ([]string, bool) {
+	if deprecated == "" {
+		return nil, false
+	}
+
+	for _, oldName := range strings.Split(deprecated, ",") {
+		if values, exists := m.values(path+oldName, options); exists {
+			m.used[path+oldName] = true
+			m.aliases[path+name] = path + oldName
+			m.warnings = append(m.warnings, Error{
+				FieldNames:     []string{path + oldName},
+				Classification: DeprecatedError,
+				Message:        "Deprecated, use " + path + name,
+				Severity:       SeverityWarning,
+			})
+			return values, true
+		}
+	}
+	return nil, false
+}
+
+// values returns the values posted for key, from the source selected by the tag options.
+func (m *formMapper) values(key string, options tagOptions) ([]string, bool) {
+	source := m.defaults
+	if options.Has("body") {
+		source = m.body
+	} else if options.Has("query") {
+		source = m.query
+	}
+	values, exists := source[key]
+	return values, exists
+}
+
+// bind marks key as bound to a field.
+func (m *formMapper) bind(key string) {
+	m.used[key] = true
+	m.bound++
+}
+
+// collectStats adds the counters of this mapping to stats.
+func (m *formMapper) collectStats(stats *Stats) {
+	stats.FieldsBound += m.bound
+	stats.FieldsSkipped += m.skipped
+	stats.UnknownKeys += len(m.unknownKeys())
+	for key, alias := range m.aliases {
+		if stats.Aliases == nil {
+			stats.Aliases = make(map[string]string)
+		}
+		stats.Aliases[key] = alias
+	}
+	for _, files := range m.formfile {
+		stats.FilesReceived += len(files)
+	}
+	for _, files := range m.storedFiles {
+		stats.FilesReceived += len(files)
+	}
+}
+
+// hasPrefix reports whether any posted value or file key starts with prefix.
+func (m *formMapper) hasPrefix(prefix string) bool {
+	for key := range m.form {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for key := range m.formfile {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for key := range m.storedFiles {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// files returns the uploaded files posted under key, as stored by the
+// UploadStore or read by ReadForm.
+func (m *formMapper) files(key string) []*File {
+	if files, exists := m.storedFiles[key]; exists {
+		return files
+	}
+
+	files := make([]*File, len(m.formfile[key]))
+	for i, fh := range m.formfile[key] {
+		files[i] = newFile(fh)
+	}
+	return files
+}
+
+// unknownKeys returns the sorted form and file keys that were not bound to any field.
+func (m *formMapper) unknownKeys() []string {
+	keys := []string{}
+	for key := range m.form {
+		if !m.used[key] {
+			keys = append(keys, key)
+		}
+	}
+	for key := range m.formfile {
+		if _, exists := m.form[key]; !exists && !m.used[key] {
+			keys = append(keys, key)
+		}
+	}
+	for key := range m.storedFiles {
+		if _, exists := m.form[key]; !exists && !m.used[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mapStruct maps the form into the struct and hands the files that no field
+// claimed to the file map fields.
+func (m *formMapper) mapStruct(formStruct reflect.Value) error {
+	if err := m.mapForm("", formStruct); err != nil {
+		return err
+	}
+	if err := m.setFields(formStruct); err != nil {
+		return err
+	}
+
+	unclaimed := []string{}
+	for key, inputFile := range m.formfile {
+		if !m.used[key] && len(inputFile) > 0 {
+			unclaimed = append(unclaimed, key)
+		}
+	}
+	if len(unclaimed) == 0 {
+		return nil
+	}
+
+	for _, structField := range m.fileCatchAlls {
+		files := reflect.MakeMap(structField.Type())
+		for _, key := range unclaimed {
+			if structField.Type() == fhMapType {
+				files.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(m.formfile[key][0]))
+			} else {
+				files.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(m.formfile[key]))
+			}
+		}
+		structField.Set(files)
+		m.bound++
+	}
+
+	if len(m.fileCatchAlls) > 0 {
+		for _, key := range unclaimed {
+			m.used[key] = true
+		}
+	}
+	return nil
+}
+
+// FieldSetter is implemented by structs that take the form keys none of their
+// fields bind, like a dynamic model backed by a map.
+type FieldSetter interface {
+	// SetField is called with every unbound key and its values. It reports
+	// whether it used the key; an error fails the binding.
+	SetField(name string, values []string) (bool, error)
+}
+
+// setFields hands the form values without a matching field to the struct when
+// it implements FieldSetter. An error that is not Errors is reported as a
+// DeserializationError on the key.
+func (m *formMapper) setFields(formStruct reflect.Value) error {
+	if !formStruct.CanAddr() {
+		return nil
+	}
+	setter, ok := formStruct.Addr().Interface().(FieldSetter)
+	if !ok {
+		return nil
+	}
+
+	for _, key := range m.unknownKeys() {
+		values, exists := m.form[key]
+		if !exists {
+			continue
+		}
+
+		used, err := setter.SetField(key, values)
+		if errors, ok := err.(Errors); ok {
+			return errors
+		} else if err != nil {
+			return Errors{NewFieldError(key, DeserializationError, err.Error())}
+		}
+		if used {
+			m.bind(key)
+		}
+	}
+	return nil
+}
 
 // Takes values from the form data and puts them into a struct
-func mapForm(path string, formStruct reflect.Value, form map[string][]string, formfile map[string][]*multipart.FileHeader) error {
+func (m *formMapper) mapForm(path string, formStruct reflect.Value) error {
 	formStruct = reflect.Indirect(formStruct)
 	typ := formStruct.Type()
 
@@ -314,29 +1158,92 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 		typeField := typ.Field(i)
 		structField := formStruct.Field(i)
 
-		inputFieldName := typeField.Tag.Get("form")
+		formTag := typeField.Tag.Get("form")
+		inputFieldName, tagOptions := parseTag(formTag)
+		//embedded structs with a name in their form tag are nested under that name
+		flatten := typeField.Anonymous && (inputFieldName == "" || inputFieldName == "-")
 		if inputFieldName == "" {
 			inputFieldName = strings.ToLower(typeField.Name)
 		}
+		inputFieldName = normalizeFormKey(inputFieldName)
 
-		if typeField.Anonymous {
+		//channels, functions and locks can never be bound
+		if isUnbindable(typeField.Type) {
+			if m.binder.WarnUnbindable {
+				m.warnings = append(m.warnings, Error{
+					FieldNames:     []string{path + inputFieldName},
+					Classification: UnbindableError,
+					Message:        "Unbindable " + typeField.Type.String(),
+					Severity:       SeverityWarning,
+				})
+			}
+			continue
+		}
+
+		//a honeypot field is hidden from people, only bots fill it in
+		if typeField.Tag.Get("honeypot") == "true" {
+			m.used[path+inputFieldName] = true
+			values, _ := m.values(path+inputFieldName, tagOptions)
+			if strings.Join(values, "") != "" {
+				if !m.binder.FlagSpam {
+					return Errors{Error{Classification: SpamError, Message: "Spam"}}
+				}
+				m.warnings = append(m.warnings, Error{Classification: SpamError, Message: "Spam", Severity: SeverityWarning})
+			}
+			continue
+		}
+
+		//the raw body field is only bound by the text binding
+		if isRawBodyField(typeField) {
+			continue
+		}
+
+		//a key posted more than once for a field holding a single value, whatever its type
+		if !flatten && m.binder.MultipleValues == RejectMultipleValues && singleValued(typeField.Type) &&
+			(formTag != "" || !isBasicKind(typeField.Type.Kind())) {
+			if values, exists := m.values(path+inputFieldName, tagOptions); exists && len(values) > 1 {
+				return Errors{Error{FieldNames: []string{path + inputFieldName}, Classification: MultipleValuesError, Message: "Multiple values"}}
+			}
+		}
+
+		if typeField.Tag.Get("csrf") == "true" {
+			token := csrfToken{key: path + inputFieldName}
+			if values, exists := m.values(path+inputFieldName, tagOptions); exists && len(values) > 0 {
+				token.token = values[0]
+			}
+			m.csrfTokens = append(m.csrfTokens, token)
+		}
+
+		if flatten {
 			if typeField.Type.Kind() == reflect.Ptr {
+				bound := len(m.used)
 				structField.Set(reflect.New(typeField.Type.Elem()))
-				if err := mapForm(path, structField.Elem(), form, formfile); err != nil {
+				if err := m.mapForm(path, structField.Elem()); err != nil {
 					return err
 				}
-				if reflect.DeepEqual(structField.Elem().Interface(), reflect.Zero(structField.Elem().Type()).Interface()) {
+				if m.binder.KeepZeroEmbeds || tagOptions.Has("keepzero") {
+					//only collapse when none of the embedded fields were submitted
+					if len(m.used) == bound {
+						structField.Set(reflect.Zero(structField.Type()))
+					}
+				} else if reflect.DeepEqual(structField.Elem().Interface(), reflect.Zero(structField.Elem().Type()).Interface()) {
 					structField.Set(reflect.Zero(structField.Type()))
 				}
 			} else {
-				if err := mapForm(path, structField, form, formfile); err != nil {
+				if err := m.mapForm(path, structField); err != nil {
 					return err
 				}
 			}
+		} else if tagOptions.Has("json") {
+			//a value or file part holding a json document
+			if err := m.setJSON(path+inputFieldName, tagOptions, structField); err != nil {
+				return err
+			}
 		} else if structField.Kind() == reflect.Slice && structField.Type().Elem() == fhType {
 			//slice of file uploads
-			inputFile, exists := formfile[path+inputFieldName]
+			inputFile, exists := m.formfile[path+inputFieldName]
 			if exists {
+				m.bind(path + inputFieldName)
 				numFiles := len(inputFile)
 				if numFiles > 0 {
 					slice := reflect.MakeSlice(structField.Type(), numFiles, numFiles)
@@ -345,28 +1252,142 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 					}
 					structField.Set(slice)
 				}
+			} else {
+				m.skipped++
 			}
 		} else if structField.Type() == fhType {
 			//single file
-			inputFile, exists := formfile[path+inputFieldName]
+			inputFile, exists := m.formfile[path+inputFieldName]
 			if exists && len(inputFile) >= 1 {
+				m.bind(path + inputFieldName)
 				structField.Set(reflect.ValueOf(inputFile[0]))
+			} else {
+				m.skipped++
+			}
+		} else if structField.Type() == fileSliceType {
+			//slice of file uploads with metadata
+			if files := m.files(path + inputFieldName); len(files) > 0 {
+				m.bind(path + inputFieldName)
+				structField.Set(reflect.ValueOf(files))
+			} else {
+				m.skipped++
+			}
+		} else if structField.Type() == fileType {
+			//single file upload with metadata
+			if files := m.files(path + inputFieldName); len(files) > 0 {
+				m.bind(path + inputFieldName)
+				structField.Set(reflect.ValueOf(files[0]))
+			} else {
+				m.skipped++
+			}
+		} else if structField.Type() == fhMapType || structField.Type() == fhSliceMapType {
+			//catch-all for the files not claimed by other fields, assigned after mapping
+			if structField.CanSet() {
+				m.fileCatchAlls = append(m.fileCatchAlls, structField)
+			}
+		} else if typeField.Type == bytesType && !isRawBodyField(typeField) {
+			inputValue, exists := m.values(path+inputFieldName, tagOptions)
+			if exists && len(inputValue) > 0 && structField.CanSet() {
+				m.bind(path + inputFieldName)
+				data, err := decodeBinary(m.binaryEncoding(typeField), inputValue[0])
+				if err != nil {
+					return Errors{NewFieldError(path+inputFieldName, EncodingError, "Invalid encoding")}
+				}
+				structField.SetBytes(data)
+			} else {
+				m.skipped++
+			}
+		} else if isTextUnmarshaler(typeField.Type) {
+			inputValue, exists := m.values(path+inputFieldName, tagOptions)
+			if exists && len(inputValue) > 0 && inputValue[0] != "" && structField.CanSet() {
+				m.bind(path + inputFieldName)
+				if err := setText(inputValue[0], structField); err != nil {
+					return Errors{NewFieldError(path+inputFieldName, DeserializationError, "Malformed value")}
+				}
+			} else {
+				m.skipped++
+			}
+		} else if isBinaryUnmarshaler(typeField.Type) {
+			inputValue, exists := m.values(path+inputFieldName, tagOptions)
+			if exists && len(inputValue) > 0 && structField.CanSet() {
+				m.bind(path + inputFieldName)
+				data, err := decodeBinary(m.binaryEncoding(typeField), inputValue[0])
+				if err != nil {
+					return Errors{NewFieldError(path+inputFieldName, EncodingError, "Invalid encoding")}
+				}
+				if err := setBinary(data, structField); err != nil {
+					return Errors{NewFieldError(path+inputFieldName, DeserializationError, "Malformed value")}
+				}
+			} else {
+				m.skipped++
+			}
+		} else if valueType, ok := lookupValueType(typeField.Type); ok {
+			inputValue, exists := m.values(path+inputFieldName, tagOptions)
+			if exists && len(inputValue) > 0 && inputValue[0] != "" && structField.CanSet() {
+				m.bind(path + inputFieldName)
+				value, err := valueType.parse(inputValue[0])
+				if err != nil {
+					return Errors{NewFieldError(path+inputFieldName, valueType.classification, valueType.message)}
+				}
+				structField.Set(reflect.ValueOf(value))
+			} else {
+				m.skipped++
+			}
+		} else if valueType, ok := lookupValueType(sliceElem(typeField.Type)); ok {
+			inputValue, exists := m.values(path+inputFieldName, tagOptions)
+			if exists && len(inputValue) > 0 && structField.CanSet() {
+				m.bind(path + inputFieldName)
+				slice := reflect.MakeSlice(typeField.Type, 0, len(inputValue))
+				for _, input := range inputValue {
+					value, err := valueType.parse(input)
+					if err != nil {
+						return Errors{NewFieldError(path+inputFieldName, valueType.classification, valueType.message)}
+					}
+					slice = reflect.Append(slice, reflect.ValueOf(value))
+				}
+				structField.Set(slice)
+			} else {
+				m.skipped++
+			}
+		} else if typeField.Type == timeType || typeField.Type == reflect.PtrTo(timeType) {
+			inputValue, exists := m.values(path+inputFieldName, tagOptions)
+			if exists && len(inputValue) > 0 && structField.CanSet() {
+				m.bind(path + inputFieldName)
+				//a *time.Time is only allocated for a valid time
+				value := reflect.New(timeType)
+				if !m.binder.setTime(inputValue[0], value.Elem(), typeField) {
+					return Errors{NewFieldError(path+inputFieldName, TimeTypeError, "Invalid time")}
+				}
+				if structField.Kind() == reflect.Ptr {
+					structField.Set(value)
+				} else {
+					structField.Set(value.Elem())
+				}
+			} else {
+				m.skipped++
 			}
 		} else if typeField.Type.Kind() == reflect.Ptr && typeField.Type.Elem().Kind() == reflect.Struct {
 			//find if we have posted this field and or need to init the pointer
-			for key, _ := range form {
-				if strings.HasPrefix(key, path+inputFieldName+".") {
-					if structField.IsNil() {
-						structField.Set(reflect.New(typeField.Type.Elem()))
-					}
-					if err := mapForm(path+inputFieldName+".", structField.Elem(), form, formfile); err != nil {
-						return err
-					}
-					break
+			if structField.CanSet() && m.hasPrefix(path+inputFieldName+".") {
+				if structField.IsNil() {
+					structField.Set(reflect.New(typeField.Type.Elem()))
+				}
+				if err := m.mapForm(path+inputFieldName+".", structField.Elem()); err != nil {
+					return err
 				}
 			}
+		} else if pair, exists := m.values(path+inputFieldName, tagOptions); typeField.Type == pointType && exists {
+			//a coordinate pair in a single value, else the nested lat and lng keys
+			if len(pair) > 0 && structField.CanSet() {
+				m.bind(path + inputFieldName)
+				point, err := ParsePoint(pair[0])
+				if err != nil {
+					return Errors{NewFieldError(path+inputFieldName, DeserializationError, "Malformed point")}
+				}
+				structField.Set(reflect.ValueOf(point))
+			}
 		} else if typeField.Type.Kind() == reflect.Struct {
-			if err := mapForm(path+inputFieldName+".", structField, form, formfile); err != nil {
+			if err := m.mapForm(path+inputFieldName+".", structField); err != nil {
 				return err
 			}
 		} else if typeField.Type.Kind() == reflect.Slice &&
@@ -374,7 +1395,7 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 				(typeField.Type.Elem().Kind() == reflect.Ptr && typeField.Type.Elem().Elem().Kind() == reflect.Struct)) {
 
 			//size slice (if necessary)
-			size := pathSliceSize(path+inputFieldName, form)
+			size := pathSliceSize(path+inputFieldName, m.form)
 			if structField.Len() < size {
 				value := reflect.MakeSlice(structField.Type(), size, size)
 				if structField.Len() > 0 {
@@ -389,18 +1410,49 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 				if sliceValue.Kind() == reflect.Ptr && sliceValue.IsNil() {
 					sliceValue.Set(reflect.New(sliceValue.Type().Elem()))
 				}
-				if err := mapForm(path+inputFieldName+"."+strconv.Itoa(i)+".", sliceValue, form, formfile); err != nil {
+				if err := m.mapForm(path+inputFieldName+"."+strconv.Itoa(i)+".", sliceValue); err != nil {
 					return err
 				}
 			}
 
-		} else if inputFieldName := typeField.Tag.Get("form"); inputFieldName != "" {
+		} else if formTag != "" {
 			if !structField.CanSet() {
+				m.skipped++
 				continue
 			}
 
-			inputValue, exists := form[path+inputFieldName]
-			if exists {
+			inputValue, exists := m.values(path+inputFieldName, tagOptions)
+			if !exists {
+				inputValue, exists = m.deprecatedValues(path, inputFieldName, typeField.Tag.Get("deprecated"), tagOptions)
+			}
+			if !exists {
+				m.skipped++
+			} else {
+				m.bind(path + inputFieldName)
+				elemType := typeField.Type
+				if elemType.Kind() == reflect.Slice {
+					elemType = elemType.Elem()
+				}
+				if elemType.Kind() == reflect.Ptr {
+					elemType = elemType.Elem()
+				}
+				kind := elemType.Kind()
+				if m.binder.RelaxedNumbers {
+					inputValue = relaxedNumbers(inputValue, kind)
+				}
+				var ok bool
+				if unit := typeField.Tag.Get("unit"); unit != "" {
+					if inputValue, ok = unitValues(inputValue, unit); !ok {
+						return Errors{NewFieldError(path+inputFieldName, UnitError, "Invalid unit")}
+					}
+				} else if elemType == durationType {
+					if inputValue, ok = unitValues(inputValue, "duration"); !ok {
+						return Errors{NewFieldError(path+inputFieldName, DurationError, "Invalid duration")}
+					}
+				}
+				if inputValue, ok = integerValues(inputValue, typeField.Tag.Get("float"), kind); !ok {
+					return Errors{NewFieldError(path+inputFieldName, IntegerTypeError, "Not an integer")}
+				}
 				numElems := len(inputValue)
 				if structField.Kind() == reflect.Slice && numElems > 0 {
 					sliceOf := structField.Type().Elem().Kind()
@@ -409,8 +1461,13 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 						setWithProperType(sliceOf, inputValue[i], slice.Index(i), inputFieldName)
 					}
 					formStruct.Field(i).Set(slice)
-				} else {
-					setWithProperType(typeField.Type.Kind(), inputValue[0], structField, inputFieldName)
+				} else if numElems > 1 && m.binder.MultipleValues == RejectMultipleValues {
+					return Errors{Error{FieldNames: []string{path + inputFieldName}, Classification: MultipleValuesError, Message: "Multiple values"}}
+				} else if numElems > 0 && !setWithProperType(typeField.Type.Kind(), inputValue[0], structField, inputFieldName) &&
+					typeField.Type.Kind() == reflect.Ptr {
+					//a pointer left nil would read as not sent, so its invalid value is reported
+					classification, message := conversionError(typeField.Type)
+					return Errors{NewFieldError(path+inputFieldName, classification, message)}
 				}
 			}
 		}
@@ -418,11 +1475,38 @@ func mapForm(path string, formStruct reflect.Value, form map[string][]string, fo
 	return nil
 }
 
+// singleValued reports whether a field of type typ binds a single value, so
+// the MultipleValues policy applies to it. Byte slices and the value types,
+// like net.IP, and the types unmarshaling a value are single values; other
+// slices, arrays and maps are not.
+func singleValued(typ reflect.Type) bool {
+	if _, ok := lookupValueType(typ); ok || typ == bytesType || isTextUnmarshaler(typ) || isBinaryUnmarshaler(typ) {
+		return true
+	}
+	if typ.Kind() == reflect.Ptr {
+		return singleValued(typ.Elem())
+	}
+	kind := typ.Kind()
+	return kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map
+}
+
+// isBasicKind reports whether kind is a boolean, number or string; fields of
+// those kinds are only bound when they have a form tag.
+func isBasicKind(kind reflect.Kind) bool {
+	return kind == reflect.String || (kind >= reflect.Bool && kind <= reflect.Complex128)
+}
+
 // This sets the value in a struct of an indeterminate type to the
 // matching value from the request (via Form middleware) in the
 // same type, so that not all deserialize values have to be strings.
-// Supported types are string, int, float, and bool.
-func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value, nameInTag string) {
+// Supported types are string, int, float, bool, time.Duration, time.Time and
+// types implementing encoding.TextUnmarshaler, and pointers to them. It
+// reports false when the value does not convert into the type of the field.
+func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value, nameInTag string) bool {
+	if structField.CanSet() && isTextUnmarshaler(structField.Type()) {
+		return setText(val, structField) == nil
+	}
+
 	switch valueKind {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if val == "" {
@@ -431,45 +1515,77 @@ func setWithProperType(valueKind reflect.Kind, val string, structField reflect.V
 
 		if intVal, err := strconv.ParseInt(val, 10, 64); err == nil {
 			structField.SetInt(intVal)
+		} else if structField.Type() == durationType {
+			duration, err := time.ParseDuration(val)
+			if err != nil {
+				return false
+			}
+			structField.SetInt(int64(duration))
+		} else {
+			return false
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if val == "" {
 			val = "0"
 		}
 
-		if uintVal, err := strconv.ParseUint(val, 10, 64); err == nil {
-			structField.SetUint(uintVal)
+		uintVal, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return false
 		}
+		structField.SetUint(uintVal)
 	case reflect.Bool:
 		if val == "on" {
 			structField.SetBool(true)
-			return
+			return true
 		}
 
 		if val == "" {
 			val = "false"
 		}
 
-		if boolVal, err := strconv.ParseBool(val); err == nil {
-			structField.SetBool(boolVal)
+		boolVal, err := strconv.ParseBool(val)
+		if err != nil {
+			return false
 		}
+		structField.SetBool(boolVal)
 	case reflect.Float32:
 		if val == "" {
 			val = "0.0"
 		}
 
-		if floatVal, err := strconv.ParseFloat(val, 32); err == nil {
-			structField.SetFloat(floatVal)
+		floatVal, err := strconv.ParseFloat(val, 32)
+		if err != nil {
+			return false
 		}
+		structField.SetFloat(floatVal)
 	case reflect.Float64:
 		if val == "" {
 			val = "0.0"
 		}
 
-		if floatVal, err := strconv.ParseFloat(val, 64); err == nil {
-			structField.SetFloat(floatVal)
+		floatVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return false
 		}
+		structField.SetFloat(floatVal)
 	case reflect.String:
 		structField.SetString(val)
+	case reflect.Ptr:
+		//allocated only for a value that is sent and converts, so nil tells it apart from the zero value
+		value := reflect.New(structField.Type().Elem())
+		if !setWithProperType(structField.Type().Elem().Kind(), val, value.Elem(), nameInTag) {
+			return false
+		}
+		structField.Set(value)
+	case reflect.Struct:
+		if structField.Type() == timeType {
+			t, ok := parseTime("", val, time.UTC)
+			if !ok {
+				return false
+			}
+			structField.Set(reflect.ValueOf(t))
+		}
 	}
+	return true
 }
@@ -0,0 +1,109 @@
+package binding
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// bodyCheck verifies a property of a request body that is only known once
+// all of the body was read, like its digest or its length.
+type bodyCheck interface {
+	// finish completes the check after the binding returned err and returns
+	// the error the binding should fail with.
+	finish(err error) error
+}
+
+// pendingBodyChecks holds the checks of the requests being bound.
+var pendingBodyChecks sync.Map
+
+// addBodyCheck registers a check to complete when the binding of req is done.
+func addBodyCheck(req *http.Request, check bodyCheck) {
+	checks, _ := pendingBodyChecks.Load(req)
+	list, _ := checks.([]bodyCheck)
+	pendingBodyChecks.Store(req, append(list, check))
+}
+
+// finishBodyChecks completes the checks registered for req.
+func finishBodyChecks(req *http.Request, err error) error {
+	checks, ok := pendingBodyChecks.LoadAndDelete(req)
+	if !ok {
+		return err
+	}
+	for _, check := range checks.([]bodyCheck) {
+		err = check.finish(err)
+	}
+	return err
+}
+
+// checkContentLength rejects requests with a body but without a Content-Length
+// header when RequireContentLength is set, and wraps the body to compare the
+// bytes read with the declared length when VerifyContentLength is set.
+func (b *Binder) checkContentLength(req *http.Request) error {
+	hasBody := req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH"
+	if b.RequireContentLength && hasBody && len(req.TransferEncoding) == 0 &&
+		req.ContentLength <= 0 && req.Header.Get("Content-Length") == "" {
+		return ErrorLengthRequired
+	}
+
+	if !b.VerifyContentLength || req.Body == nil || req.ContentLength < 0 {
+		return nil
+	}
+
+	reader := &lengthReader{ReadCloser: req.Body, declared: req.ContentLength, tolerance: b.ContentLengthTolerance}
+	req.Body = reader
+	addBodyCheck(req, reader)
+	return nil
+}
+
+// lengthReader compares the number of bytes read from a body with its declared
+// Content-Length, allowing a difference of tolerance bytes.
+type lengthReader struct {
+	io.ReadCloser
+	declared  int64
+	tolerance int64
+	read      int64
+	finished  bool
+	mismatch  bool
+}
+
+func (r *lengthReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+	if r.read > r.declared+r.tolerance {
+		r.mismatch = true
+	}
+	if (err == io.EOF || err == io.ErrUnexpectedEOF) && !r.finished {
+		r.finished = true
+		if r.read < r.declared-r.tolerance {
+			r.mismatch = true
+		}
+	}
+	if r.mismatch {
+		err = ErrorContentLength
+	}
+	return n, err
+}
+
+// Close reads the rest of the body, so the length covers all of it.
+func (r *lengthReader) Close() error {
+	r.drain()
+	return r.ReadCloser.Close()
+}
+
+func (r *lengthReader) drain() {
+	if !r.finished {
+		io.Copy(io.Discard, r)
+	}
+}
+
+// finish completes the length check once the binding is done with the body.
+func (r *lengthReader) finish(err error) error {
+	if err == nil || IsInvalid(err) {
+		r.drain()
+	}
+	if r.mismatch {
+		return ErrorContentLength
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package binding
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type bodyCheckSuite struct{}
+
+var _ = Suite(&bodyCheckSuite{})
+
+func (s *bodyCheckSuite) Test_ContentLengthMatches(c *C) {
+	stats := &Stats{}
+	req := WithStats(newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`+"\n\n", jsonContentType), stats)
+	binder := &Binder{VerifyContentLength: true}
+	post := Post{}
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+	c.Assert(stats.BytesRead, Equals, int64(34))
+}
+
+func (s *bodyCheckSuite) Test_ContentLengthTooShort(c *C) {
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title`, formContentType)
+	req.ContentLength = 40
+	binder := &Binder{VerifyContentLength: true}
+	err := binder.Bind(&Post{}, req)
+
+	c.Assert(err, Equals, ErrorContentLength)
+	c.Assert(StatusCode(err), Equals, http.StatusBadRequest)
+}
+
+func (s *bodyCheckSuite) Test_ContentLengthTooLong(c *C) {
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.ContentLength = 10
+	binder := &Binder{VerifyContentLength: true}
+
+	c.Assert(binder.Bind(&Post{}, req), Equals, ErrorContentLength)
+}
+
+func (s *bodyCheckSuite) Test_ContentLengthTolerance(c *C) {
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.ContentLength = 30
+	binder := &Binder{VerifyContentLength: true, ContentLengthTolerance: 2}
+
+	c.Assert(binder.Bind(&Post{}, req), IsNil)
+}
+
+func (s *bodyCheckSuite) Test_RequireContentLength(c *C) {
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.ContentLength = 0
+	binder := &Binder{RequireContentLength: true}
+	err := binder.Bind(&Post{}, req)
+
+	c.Assert(err, Equals, ErrorLengthRequired)
+	c.Assert(StatusCode(err), Equals, http.StatusLengthRequired)
+
+	req = newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.ContentLength = -1
+	req.TransferEncoding = []string{"chunked"}
+
+	c.Assert(binder.Bind(&Post{}, req), IsNil)
+}
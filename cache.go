@@ -0,0 +1,77 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+type cacheContextKey struct{}
+
+// WithCache returns a shallow copy of req that memoizes form bindings: binding
+// the same model type with the same binder again, for example from several
+// middlewares, reuses the first result instead of mapping and validating the
+// request again. The cached value is a shallow copy, so slices and maps are
+// shared between the bound values; the errors are copied. A failure is
+// reported to OnFailure by the first binding only.
+func WithCache(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), cacheContextKey{}, &bindCache{}))
+}
+
+type bindCacheKey struct {
+	binder *Binder
+	model  reflect.Type
+}
+
+type bindCacheEntry struct {
+	value reflect.Value
+	err   error
+}
+
+type bindCache struct {
+	sync.Mutex
+	entries map[bindCacheKey]bindCacheEntry
+}
+
+// requestCache returns the cache attached to the request, or nil.
+func requestCache(req *http.Request) *bindCache {
+	cache, _ := req.Context().Value(cacheContextKey{}).(*bindCache)
+	return cache
+}
+
+// load copies a cached result for the model into v and returns its entry,
+// with a copy of its errors.
+func (c *bindCache) load(binder *Binder, v reflect.Value) (bindCacheEntry, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, ok := c.entries[bindCacheKey{binder: binder, model: v.Type()}]
+	if ok {
+		v.Set(entry.value)
+		entry.err = copyErrors(entry.err)
+	}
+	return entry, ok
+}
+
+// store keeps a copy of the bound value v and the error of the binding.
+func (c *bindCache) store(binder *Binder, v reflect.Value, err error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[bindCacheKey]bindCacheEntry)
+	}
+	value := reflect.New(v.Type()).Elem()
+	value.Set(v)
+	c.entries[bindCacheKey{binder: binder, model: v.Type()}] = bindCacheEntry{value: value, err: copyErrors(err)}
+}
+
+// copyErrors returns a copy of err when it is Errors, so the callers sharing a
+// cached result can not change the errors of each other.
+func copyErrors(err error) error {
+	if errors, ok := err.(Errors); ok {
+		return append(Errors(nil), errors...)
+	}
+	return err
+}
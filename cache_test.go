@@ -0,0 +1,83 @@
+package binding
+
+import . "gopkg.in/check.v1"
+
+type cacheSuite struct{}
+
+var _ = Suite(&cacheSuite{})
+
+type Pagination struct {
+	Page    int `form:"page" binding:"Range(1,100)"`
+	PerPage int `form:"per_page"`
+}
+
+func (s *cacheSuite) Test_CachedBind(c *C) {
+	req := WithCache(newRequest(`GET`, `?page=2&per_page=20`, ``, ``))
+
+	first := Pagination{}
+	err := Form.Bind(&first, req)
+	c.Assert(err, IsNil)
+	c.Assert(first, DeepEquals, Pagination{Page: 2, PerPage: 20})
+
+	// a cache hit does not look at the form again
+	req.Form.Set("page", "3")
+	second := Pagination{}
+	err = Form.Bind(&second, req)
+	c.Assert(err, IsNil)
+	c.Assert(second, DeepEquals, first)
+}
+
+func (s *cacheSuite) Test_CachedBindError(c *C) {
+	req := WithCache(newRequest(`GET`, `?page=0`, ``, ``))
+
+	first := Pagination{}
+	err := Form.Bind(&first, req)
+	c.Assert(err, NotNil)
+
+	second := Pagination{}
+	c.Assert(Form.Bind(&second, req), DeepEquals, err)
+}
+
+func (s *cacheSuite) Test_CachePerBinder(c *C) {
+	req := WithCache(newRequest(`GET`, `?page=2&page=3`, ``, ``))
+
+	first := Pagination{}
+	err := Form.Bind(&first, req)
+	c.Assert(err, IsNil)
+
+	second := Pagination{}
+	binder := &Binder{MultipleValues: RejectMultipleValues}
+	err = binder.Form().Bind(&second, req)
+	c.Assert(err, NotNil)
+}
+
+func (s *cacheSuite) Test_NoCache(c *C) {
+	req := newRequest(`GET`, `?page=2`, ``, ``)
+
+	first := Pagination{}
+	c.Assert(Form.Bind(&first, req), IsNil)
+
+	req.Form.Set("page", "3")
+	second := Pagination{}
+	c.Assert(Form.Bind(&second, req), IsNil)
+	c.Assert(second.Page, Equals, 3)
+}
+
+func (s *cacheSuite) Test_CachedBindReportsFailureOnce(c *C) {
+	req := WithCache(newRequest(`GET`, `?page=0`, ``, ``))
+	failures := 0
+	binder := &Binder{Provenance: true, OnFailure: func(failure Failure) { failures++ }, FailureClassifications: []string{RangeError}}
+
+	first := Pagination{}
+	err := binder.Form().Bind(&first, req)
+	c.Assert(err, NotNil)
+
+	second := Pagination{}
+	cached := binder.Form().Bind(&second, req)
+	c.Assert(cached, DeepEquals, err)
+	c.Assert(failures, Equals, 1)
+
+	cached.(Errors)[0].Message = "Changed"
+	third := Pagination{}
+	c.Assert(binder.Form().Bind(&third, req), DeepEquals, err)
+}
@@ -0,0 +1,21 @@
+package binding
+
+import "net/http"
+
+type cborBinding struct {
+	binder *Binder
+}
+
+func (_ cborBinding) Name() string {
+	return "cbor"
+}
+
+// CBOR deserializes a CBOR payload from the request into the struct that is
+// passed in and validates it like the JSON binding does. This package does not
+// depend on a CBOR library; the binding decodes with the CBORUnmarshal function
+// of the binder, for example cbor.Unmarshal from github.com/fxamacker/cbor/v2,
+// and fails with ErrorUnsupportedContentType when none is set.
+func (c cborBinding) Bind(dst interface{}, req *http.Request) error {
+	binder := binderOrDefault(c.binder)
+	return binder.unmarshalBody(dst, req, c.Name(), binder.CBORUnmarshal)
+}
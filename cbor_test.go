@@ -0,0 +1,66 @@
+package binding
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type cborSuite struct{}
+
+var _ = Suite(&cborSuite{})
+
+// unmarshalCBORText stands in for cbor.Unmarshal, it decodes a single CBOR
+// text string of up to 23 bytes into the name.
+func unmarshalCBORText(data []byte, v interface{}) error {
+	if len(data) == 0 || data[0]&0xe0 != 0x60 || int(data[0]&0x1f) != len(data)-1 {
+		return errors.New("unexpected CBOR item")
+	}
+	v.(*RequiredName).Name = string(data[1:])
+	return nil
+}
+
+func (s *cborSuite) Test_CBOR(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, "\x69Matt Holt", "application/cbor")
+	binder := &Binder{CBORUnmarshal: unmarshalCBORText}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(person, DeepEquals, RequiredName{Name: "Matt Holt"})
+}
+
+func (s *cborSuite) Test_CBORValidation(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, "\x60", "application/cbor")
+	binder := &Binder{CBORUnmarshal: unmarshalCBORText}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *cborSuite) Test_CBORMalformed(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, "\x69Matt", "application/cbor")
+	binder := &Binder{CBORUnmarshal: unmarshalCBORText}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *cborSuite) Test_CBORNotByReference(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, "\x69Matt Holt", "application/cbor")
+	binder := &Binder{CBORUnmarshal: unmarshalCBORText}
+	err := binder.Bind(person, req)
+
+	c.Assert(err, DeepEquals, ErrorInputNotByReference)
+}
+
+func (s *cborSuite) Test_CBORWithoutUnmarshal(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, "\x69Matt Holt", "application/cbor")
+	err := Bind(&person, req)
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedContentType)
+}
@@ -0,0 +1,82 @@
+package binding
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// now returns the current time of the clock of the validation.
+func (o validation) now() time.Time {
+	if o.clock != nil {
+		return o.clock()
+	}
+	return time.Now()
+}
+
+// random returns the random source of the validation.
+func (o validation) random() io.Reader {
+	if o.rand != nil {
+		return o.rand
+	}
+	return rand.Reader
+}
+
+// timeValue returns the value of a time.Time field, following pointers.
+// Zero times are not returned, those are left to the Required rule.
+func timeValue(v reflect.Value) (time.Time, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return time.Time{}, false
+		}
+		v = v.Elem()
+	}
+
+	t, ok := v.Interface().(time.Time)
+	return t, ok && !t.IsZero()
+}
+
+// ruleTime parses the argument of the Before and After rules: "now", a
+// RFC 3339 timestamp or a date.
+func (o validation) ruleTime(arg string) (time.Time, bool) {
+	if arg == "now" {
+		return o.now(), true
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, arg); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// setDefault sets a zero field to the value of a Default rule that depends on
+// the validation, the current time for Default(now) on a time.Time field and a
+// random version 4 UUID for Default(uuid) on a string field. It reports
+// whether it recognised the value.
+func (o validation) setDefault(value string, field reflect.Value) bool {
+	switch {
+	case value == "now" && field.Type() == timeType:
+		field.Set(reflect.ValueOf(o.now()))
+		return true
+	case value == "uuid" && field.Kind() == reflect.String:
+		if uuid, err := newUUID(o.random()); err == nil {
+			field.SetString(uuid)
+		}
+		return true
+	}
+	return false
+}
+
+// newUUID reads a random version 4 UUID (RFC 4122) from r.
+func newUUID(r io.Reader) (string, error) {
+	var uuid [16]byte
+	if _, err := io.ReadFull(r, uuid[:]); err != nil {
+		return "", err
+	}
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+}
@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Color is an RGB color with an alpha channel. It binds from a hex color, like
+// #f80, #ff8800 or #ff880080, or from a CSS rgb(255, 136, 0) or
+// rgba(255, 136, 0, 0.5) function, and encodes as a hex color.
+type Color struct {
+	R, G, B, A uint8
+}
+
+var colorType = reflect.TypeOf(Color{})
+
+var errColor = errors.New("not a hex or rgb color")
+
+// ParseColor parses a hex or rgb color.
+func ParseColor(value string) (Color, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case strings.HasPrefix(value, "#"):
+		return parseHexColor(value[1:])
+	case strings.HasPrefix(value, "rgba(") && strings.HasSuffix(value, ")"):
+		return parseRGBColor(value[5:len(value)-1], true)
+	case strings.HasPrefix(value, "rgb(") && strings.HasSuffix(value, ")"):
+		return parseRGBColor(value[4:len(value)-1], false)
+	}
+	return Color{}, errColor
+}
+
+func parseHexColor(hex string) (Color, error) {
+	if len(hex) == 3 || len(hex) == 4 {
+		expanded := make([]byte, 0, len(hex)*2)
+		for i := 0; i < len(hex); i++ {
+			expanded = append(expanded, hex[i], hex[i])
+		}
+		hex = string(expanded)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return Color{}, errColor
+	}
+
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, errColor
+	}
+	return Color{R: uint8(rgba >> 24), G: uint8(rgba >> 16), B: uint8(rgba >> 8), A: uint8(rgba)}, nil
+}
+
+func parseRGBColor(args string, alpha bool) (Color, error) {
+	parts := strings.Split(args, ",")
+	if (alpha && len(parts) != 4) || (!alpha && len(parts) != 3) {
+		return Color{}, errColor
+	}
+
+	channels := [4]uint8{3: 255}
+	for i, part := range parts[:3] {
+		channel, err := strconv.ParseUint(strings.TrimSpace(part), 10, 8)
+		if err != nil {
+			return Color{}, errColor
+		}
+		channels[i] = uint8(channel)
+	}
+	if alpha {
+		opacity, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+		if err != nil || opacity < 0 || opacity > 1 {
+			return Color{}, errColor
+		}
+		channels[3] = uint8(opacity*255 + 0.5)
+	}
+	return Color{R: channels[0], G: channels[1], B: channels[2], A: channels[3]}, nil
+}
+
+// String returns the color as #rrggbb, or as #rrggbbaa when it is not opaque.
+func (c Color) String() string {
+	if c.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// MarshalText encodes the color as a hex color.
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a hex or rgb color.
+func (c *Color) UnmarshalText(text []byte) error {
+	color, err := ParseColor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = color
+	return nil
+}
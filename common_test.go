@@ -48,6 +48,10 @@ type (
 	EmbedPerson struct {
 		*Person
 	}
+
+	KeepZeroEmbedPerson struct {
+		*Person `form:",keepzero"`
+	}
 )
 
 func newRequest(method, query, body, contentType string) *http.Request {
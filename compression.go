@@ -0,0 +1,76 @@
+package binding
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decodeBody prepares the body of a request for a binding: it is checked,
+// decompressed and capped at MaxRequestSize bytes and the MaxBytes budget of
+// the Limits. A larger Content-Length fails with ErrorRequestTooLarge or a
+// LimitExceededError before anything is read.
+func (b *Binder) decodeBody(req *http.Request) error {
+	if b.MaxRequestSize > 0 && req.ContentLength > b.MaxRequestSize {
+		return ErrorRequestTooLarge
+	}
+	if b.Limits.MaxBytes > 0 && req.ContentLength > b.Limits.MaxBytes {
+		return limitExceeded("MaxBytes")
+	}
+	if err := b.decompressBody(req); err != nil {
+		return err
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	req.Body = b.Limits.budget(req.Body)
+	if b.MaxRequestSize > 0 {
+		req.Body = http.MaxBytesReader(nil, req.Body, b.MaxRequestSize)
+	}
+	return nil
+}
+
+// decompressBody replaces the body of a request with a Content-Encoding by a reader
+// that decompresses it, limited to MaxDecompressedSize bytes. The header is
+// removed, so binding the request again does not decompress twice. Encodings
+// other than gzip, deflate and those in Decompressors fail with
+// ErrorUnsupportedContentType. The body is first wrapped to verify its length
+// and digest when the VerifyContentLength and VerifyDigest options are set.
+func (b *Binder) decompressBody(req *http.Request) error {
+	if err := b.checkContentLength(req); err != nil {
+		return err
+	}
+	if err := b.verifyDigest(req); err != nil {
+		return err
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" || req.Body == nil {
+		return nil
+	}
+
+	var body io.ReadCloser
+	var err error
+	if decompress, ok := b.Decompressors[encoding]; ok {
+		body, err = decompress(req.Body)
+	} else if encoding == "gzip" || encoding == "x-gzip" {
+		body, err = gzip.NewReader(req.Body)
+	} else if encoding == "deflate" {
+		body, err = zlib.NewReader(req.Body)
+	} else {
+		return ErrorUnsupportedContentType
+	}
+	if err != nil {
+		return readError(err)
+	}
+
+	if b.MaxDecompressedSize > 0 {
+		body = http.MaxBytesReader(nil, body, b.MaxDecompressedSize)
+	}
+	req.Body = body
+	req.ContentLength = -1
+	req.Header.Del("Content-Encoding")
+	return nil
+}
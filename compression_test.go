@@ -0,0 +1,90 @@
+package binding
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type compressionSuite struct{}
+
+var _ = Suite(&compressionSuite{})
+
+func gzipped(data string) string {
+	b := &bytes.Buffer{}
+	w := gzip.NewWriter(b)
+	w.Write([]byte(data))
+	w.Close()
+	return b.String()
+}
+
+func (s *compressionSuite) Test_GzipJSON(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, gzipped(`{"title": "Glorious Post Title", "content": "Lorem ipsum dolor sit amet"}`), jsonContentType)
+	req.Header.Set("Content-Encoding", "gzip")
+	err := Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
+	c.Assert(req.Header.Get("Content-Encoding"), Equals, "")
+}
+
+func (s *compressionSuite) Test_DeflateForm(c *C) {
+	b := &bytes.Buffer{}
+	w := zlib.NewWriter(b)
+	w.Write([]byte(`title=Glorious+Post+Title&content=Lorem+ipsum`))
+	w.Close()
+
+	post := Post{}
+	req := newRequest(`POST`, ``, b.String(), formContentType)
+	req.Header.Set("Content-Encoding", "deflate")
+	err := Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum"})
+}
+
+func (s *compressionSuite) Test_MaxDecompressedSize(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, gzipped(`{"title": "`+strings.Repeat("a", 4096)+`"}`), jsonContentType)
+	req.Header.Set("Content-Encoding", "gzip")
+	binder := &Binder{MaxDecompressedSize: 1024}
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, Equals, ErrorRequestTooLarge)
+}
+
+func (s *compressionSuite) Test_CorruptGzip(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "not compressed"}`, jsonContentType)
+	req.Header.Set("Content-Encoding", "gzip")
+	err := Bind(&post, req)
+
+	c.Assert(err, Equals, ErrorDeserialization)
+}
+
+func (s *compressionSuite) Test_UnsupportedEncoding(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.Header.Set("Content-Encoding", "br")
+	err := Bind(&post, req)
+
+	c.Assert(err, Equals, ErrorUnsupportedContentType)
+}
+
+func (s *compressionSuite) Test_CustomDecompressor(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.Header.Set("Content-Encoding", "br")
+	binder := &Binder{Decompressors: map[string]func(io.Reader) (io.ReadCloser, error){
+		"br": func(r io.Reader) (io.ReadCloser, error) { return io.NopCloser(r), nil },
+	}}
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
@@ -0,0 +1,24 @@
+package binding
+
+import "net/http"
+
+// csrfToken is the value posted for a field tagged with `csrf:"true"`.
+type csrfToken struct {
+	key   string
+	token string
+}
+
+// verifyCsrf passes the tokens found by the mapper to the CsrfVerifier of the
+// binder; a rejected token fails the binding with a CsrfError.
+func (b *Binder) verifyCsrf(req *http.Request, m *formMapper) error {
+	if b.CsrfVerifier == nil || b.dryRun {
+		return nil
+	}
+
+	for _, token := range m.csrfTokens {
+		if !b.CsrfVerifier(req, token.token) {
+			return Errors{Error{FieldNames: []string{token.key}, Classification: CsrfError, Message: "Invalid CSRF token"}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package binding
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type csrfSuite struct{}
+
+var _ = Suite(&csrfSuite{})
+
+type CsrfForm struct {
+	Title string `form:"title" binding:"Required"`
+	Token string `form:"_csrf" csrf:"true"`
+}
+
+func verifyToken(req *http.Request, token string) bool {
+	return token == "secret"
+}
+
+func (s *csrfSuite) Test_ValidToken(c *C) {
+	form := CsrfForm{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&_csrf=secret`, formContentType)
+	binder := &Binder{CsrfVerifier: verifyToken}
+	err := binder.Form().Bind(&form, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(form, DeepEquals, CsrfForm{Title: "Glorious Post Title", Token: "secret"})
+}
+
+func (s *csrfSuite) Test_InvalidToken(c *C) {
+	form := CsrfForm{}
+	req := newRequest(`POST`, ``, `_csrf=forged`, formContentType)
+	binder := &Binder{CsrfVerifier: verifyToken}
+	err := binder.Form().Bind(&form, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"_csrf"}, Classification: CsrfError, Message: "Invalid CSRF token"}})
+	c.Assert(StatusCode(err), Equals, http.StatusForbidden)
+}
+
+func (s *csrfSuite) Test_MissingTokenMultipart(c *C) {
+	form := CsrfForm{}
+	b, w := makeMultipartPayload(BlogPost{Post: Post{Title: "Glorious Post Title"}})
+	req := newMultipartRequest(b, w.FormDataContentType())
+	w.Close()
+	binder := &Binder{CsrfVerifier: verifyToken}
+	err := binder.MultipartForm().Bind(&form, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"_csrf"}, Classification: CsrfError, Message: "Invalid CSRF token"}})
+}
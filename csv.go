@@ -0,0 +1,211 @@
+package binding
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type csvBinding struct {
+	binder *Binder
+}
+
+func (_ csvBinding) Name() string {
+	return "csv"
+}
+
+// Csv deserializes a CSV payload from the request into the slice of structs
+// that is passed in, one element per row. The first row names the columns,
+// which are matched to the fields by their csv tag or, without one, by their
+// field name ignoring case. With the CSVWithoutHeader option of the binder
+// every row holds data and the columns are assigned to the fields in order.
+// Every row is validated; the errors of all rows are returned together, with
+// the index of the row as the first part of their field names.
+func (c csvBinding) Bind(dst interface{}, req *http.Request) (err error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	binder := binderOrDefault(c.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = binder.done(dst, req, c.Name(), err) }()
+
+	v = v.Elem()
+	if v.Kind() != reflect.Slice {
+		return ErrorInputIsNotSlice
+	}
+	elemType := v.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return ErrorInputIsNotStructure
+	}
+
+	var errors Errors
+	err = binder.readCSV(req, structType, func(index int, record reflect.Value, recordErrors Errors) error {
+		errors, _ = appendRecordErrors(errors, index, recordErrors)
+		if !record.IsValid() {
+			return nil
+		}
+
+		if elemType.Kind() == reflect.Ptr {
+			v.Set(reflect.Append(v, record))
+		} else {
+			v.Set(reflect.Append(v, record.Elem()))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if errors.Len() > 0 {
+		return errors
+	}
+	return nil
+}
+
+// readCSV reads the rows of the CSV body one at a time, binds each into a new
+// value of structType and validates it. each is called with a pointer to the
+// record and its errors, or with an invalid value for a row that failed to
+// parse. An error returned by each stops the reading and is returned as is.
+func (b *Binder) readCSV(req *http.Request, structType reflect.Type, each func(index int, record reflect.Value, recordErrors Errors) error) error {
+	if err := b.checkHeaders(req); err != nil {
+		return err
+	}
+	if req.Body == nil {
+		return nil
+	}
+	stats := requestStats(req)
+	countBody(req, stats)
+	if err := b.decodeBody(req); err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	reader := csv.NewReader(req.Body)
+	reader.FieldsPerRecord = -1
+
+	columns := csvFields(structType)
+	if !b.CSVWithoutHeader {
+		header, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return readError(err)
+		}
+		columns = csvColumns(structType, header)
+	}
+
+	for index := 0; ; index++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		_, malformed := err.(*csv.ParseError)
+		if err != nil && !malformed {
+			return readError(err)
+		}
+
+		stats.Records++
+		if malformed {
+			if err := each(index, reflect.Value{}, Errors{{Classification: DeserializationError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		record := reflect.New(structType)
+		recordErrors := setCSVRecord(record.Elem(), row, columns)
+		if err := b.validate(record.Interface(), req); err != nil {
+			validationErrors, ok := err.(Errors)
+			if !ok {
+				return err
+			}
+			recordErrors = append(recordErrors, validationErrors...)
+		}
+		if err := each(index, record, recordErrors); err != nil {
+			return err
+		}
+	}
+}
+
+// setCSVRecord sets the fields of record to the columns of the row and
+// returns the errors of the values that do not convert.
+func setCSVRecord(record reflect.Value, row []string, columns []int) Errors {
+	structType := record.Type()
+	var recordErrors Errors
+	for column, value := range row {
+		if column >= len(columns) || columns[column] < 0 {
+			continue
+		}
+
+		field := structType.Field(columns[column])
+		if field.Type == timeType {
+			t, ok := parseTime(field.Tag.Get("time_format"), value, time.UTC)
+			if !ok {
+				recordErrors = append(recordErrors, NewFieldError(field.Name, TimeTypeError, "Invalid time"))
+				continue
+			}
+			record.Field(columns[column]).Set(reflect.ValueOf(t))
+			continue
+		}
+		values, ok := []string{value}, true
+		if unit := field.Tag.Get("unit"); unit != "" {
+			if values, ok = unitValues(values, unit); !ok {
+				recordErrors = append(recordErrors, NewFieldError(field.Name, UnitError, "Invalid unit"))
+				continue
+			}
+		} else if field.Type == durationType {
+			if values, ok = unitValues(values, "duration"); !ok {
+				recordErrors = append(recordErrors, NewFieldError(field.Name, DurationError, "Invalid duration"))
+				continue
+			}
+		}
+		values, ok = integerValues(values, field.Tag.Get("float"), field.Type.Kind())
+		if !ok {
+			recordErrors = append(recordErrors, NewFieldError(field.Name, IntegerTypeError, "Not an integer"))
+			continue
+		}
+		setWithProperType(field.Type.Kind(), values[0], record.Field(columns[column]), field.Name)
+	}
+	return recordErrors
+}
+
+// csvFields returns the indexes of the fields that can be bound from a column,
+// in declaration order.
+func csvFields(typ reflect.Type) []int {
+	fields := []int{}
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.PkgPath == "" && field.Tag.Get("csv") != "-" {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+// csvColumns returns the index of the field for every column of the header,
+// or -1 for columns without a field.
+func csvColumns(typ reflect.Type, header []string) []int {
+	columns := make([]int, len(header))
+	for column, name := range header {
+		columns[column] = -1
+		for _, i := range csvFields(typ) {
+			field := typ.Field(i)
+			tagName, _ := parseTag(field.Tag.Get("csv"))
+			if tagName == strings.TrimSpace(name) || (tagName == "" && strings.EqualFold(field.Name, strings.TrimSpace(name))) {
+				columns[column] = i
+				break
+			}
+		}
+	}
+	return columns
+}
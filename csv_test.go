@@ -0,0 +1,101 @@
+package binding
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type csvSuite struct{}
+
+var _ = Suite(&csvSuite{})
+
+const csvContentType = "text/csv; charset=utf-8"
+
+type Contact struct {
+	Name  string `csv:"full name" binding:"Required"`
+	Email string `binding:"Email"`
+	Age   int
+	Notes string `csv:"-"`
+}
+
+func (s *csvSuite) Test_CSV(c *C) {
+	contacts := []Contact{}
+	req := newRequest(`POST`, ``, "email,full name,age,notes\nmatt@example.com,Matt Holt,30,secret\nmichael@example.com,Michael Boke,25,\n", csvContentType)
+	err := Bind(&contacts, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(contacts, DeepEquals, []Contact{
+		{Name: "Matt Holt", Email: "matt@example.com", Age: 30},
+		{Name: "Michael Boke", Email: "michael@example.com", Age: 25},
+	})
+}
+
+func (s *csvSuite) Test_CSVWithoutHeader(c *C) {
+	contacts := []*Contact{}
+	req := newRequest(`POST`, ``, "Matt Holt,matt@example.com,30\n", csvContentType)
+	binder := &Binder{CSVWithoutHeader: true}
+	err := binder.Bind(&contacts, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(contacts, DeepEquals, []*Contact{{Name: "Matt Holt", Email: "matt@example.com", Age: 30}})
+}
+
+func (s *csvSuite) Test_CSVRowErrors(c *C) {
+	contacts := []Contact{}
+	req := newRequest(`POST`, ``, "full name,email\nMatt Holt,matt@example.com\n,invalid\n\"Michael,michael@example.com\n", csvContentType)
+	err := Bind(&contacts, req)
+
+	errs, ok := err.(Errors)
+	c.Assert(ok, Equals, true)
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0], DeepEquals, Error{FieldNames: []string{"1.Name"}, Classification: RequiredError, Message: "Required"})
+	c.Assert(errs[1], DeepEquals, Error{FieldNames: []string{"1.Email"}, Classification: EmailError, Message: "Email"})
+	c.Assert(errs[2].FieldNames, DeepEquals, []string{"2"})
+	c.Assert(errs[2].Classification, Equals, DeserializationError)
+	c.Assert(contacts, HasLen, 2)
+}
+
+func (s *csvSuite) Test_CSVNotASlice(c *C) {
+	contact := Contact{}
+	req := newRequest(`POST`, ``, "full name\nMatt Holt\n", csvContentType)
+	err := Bind(&contact, req)
+
+	c.Assert(err, DeepEquals, ErrorInputIsNotSlice)
+}
+
+type StockLine struct {
+	Sku      string `csv:"sku"`
+	Quantity int    `csv:"quantity" float:"round"`
+	Boxes    uint   `csv:"boxes" float:"truncate"`
+	Pallets  int    `csv:"pallets" float:"error"`
+}
+
+func (s *csvSuite) Test_CSVFloatPolicy(c *C) {
+	lines := []StockLine{}
+	req := newRequest(`POST`, ``, "sku,quantity,boxes,pallets\nA-1,3.7,2.9,1\nA-2,2.5,1,1.5\n", csvContentType)
+	err := Bind(&lines, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"1.Pallets"}, Classification: IntegerTypeError, Message: "Not an integer"}})
+	c.Assert(lines, DeepEquals, []StockLine{
+		{Sku: "A-1", Quantity: 4, Boxes: 2, Pallets: 1},
+		{Sku: "A-2", Quantity: 3, Boxes: 1},
+	})
+}
+
+type Shipment struct {
+	Sku     string    `csv:"sku"`
+	Shipped time.Time `csv:"shipped" time_format:"02-01-2006"`
+}
+
+func (s *csvSuite) Test_CSVTimeFormat(c *C) {
+	shipments := []Shipment{}
+	req := newRequest(`POST`, ``, "sku,shipped\nA-1,01-06-2015\nA-2,2015-06-02\n", csvContentType)
+	err := Bind(&shipments, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"1.Shipped"}, Classification: TimeTypeError, Message: "Invalid time"}})
+	c.Assert(shipments, DeepEquals, []Shipment{
+		{Sku: "A-1", Shipped: time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{Sku: "A-2"},
+	})
+}
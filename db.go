@@ -0,0 +1,170 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DBValidator looks values up in the store of the application for the
+// Unique(table.column) and Exists(table.column) rules, like
+// `binding:"Unique(users.email)"` and `binding:"Exists(categories.id)"`.
+// Implementations are expected to be safe for concurrent use.
+type DBValidator interface {
+	// Unique reports whether no row of table holds value in column.
+	Unique(ctx context.Context, table, column string, value interface{}) (bool, error)
+
+	// Exists reports whether a row of table holds value in column.
+	Exists(ctx context.Context, table, column string, value interface{}) (bool, error)
+}
+
+// dbCheckWorkers is the number of Unique and Exists rules of a binding
+// checked against the DBValidator at the same time.
+const dbCheckWorkers = 8
+
+type dbCheck struct {
+	model  reflect.Type
+	field  reflect.StructField
+	name   string
+	rule   string
+	soft   bool
+	table  string
+	column string
+	value  interface{}
+}
+
+// isStoreRule reports whether rule is a Unique or Exists rule, which are
+// checked against the DBValidator once the other rules pass.
+func isStoreRule(rule string) bool {
+	return strings.HasPrefix(rule, "Unique(") || strings.HasPrefix(rule, "Exists(")
+}
+
+// dbChecks collects the Unique and Exists rules of the fields that hold a
+// value, following nested structs and the elements of slices of structs. The
+// fields are named like the errors of the other rules.
+func dbChecks(checks []dbCheck, val reflect.Value, path string, options validation) []dbCheck {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return checks
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return checks
+	}
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+		if field.Tag.Get("form") == "-" || !fieldVal.CanInterface() || isUnbindable(field.Type) {
+			continue
+		}
+
+		if fieldVal.Kind() == reflect.Struct || (fieldVal.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct) {
+			fieldPath := path
+			if !field.Anonymous {
+				fieldPath = path + options.fieldName(field) + "."
+			}
+			checks = dbChecks(checks, fieldVal, fieldPath, options)
+			continue
+		}
+		if field.Type.Kind() == reflect.Slice &&
+			(field.Type.Elem().Kind() == reflect.Struct ||
+				(field.Type.Elem().Kind() == reflect.Ptr && field.Type.Elem().Elem().Kind() == reflect.Struct)) {
+			for j := 0; j < fieldVal.Len(); j++ {
+				checks = dbChecks(checks, fieldVal.Index(j), path+options.fieldName(field)+"."+strconv.Itoa(j)+".", options)
+			}
+			continue
+		}
+
+		value := reflect.Indirect(fieldVal)
+		if !value.IsValid() || value.IsZero() {
+			continue
+		}
+		for _, rule := range strings.Split(field.Tag.Get(options.ruleTag()), ";") {
+			soft := strings.HasPrefix(rule, "?")
+			rule = strings.TrimPrefix(rule, "?")
+			if !isStoreRule(rule) || !strings.HasSuffix(rule, ")") {
+				continue
+			}
+			open := strings.Index(rule, "(")
+			table, column, ok := strings.Cut(rule[open+1:len(rule)-1], ".")
+			if ok {
+				checks = append(checks, dbCheck{model: typ, field: field, name: path + options.fieldName(field), rule: rule, soft: soft, table: table, column: column, value: value.Interface()})
+			}
+		}
+	}
+	return checks
+}
+
+// checkStore runs the Unique and Exists rules of dst against the DBValidator
+// of the binder, dbCheckWorkers at a time. Their violations are named,
+// classified and reported like those of the other rules, the soft rules,
+// marked with a leading ?, as warnings. An error of the store fails the
+// binding with an error wrapping ErrorStore.
+func (b *Binder) checkStore(ctx context.Context, dst interface{}, options validation) error {
+	if b.DBValidator == nil {
+		return nil
+	}
+	checks := dbChecks(nil, reflect.ValueOf(dst), "", options)
+
+	passed := make([]bool, len(checks))
+	failures := make([]error, len(checks))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < dbCheckWorkers && w < len(checks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				check := checks[i]
+				if strings.HasPrefix(check.rule, "Unique(") {
+					passed[i], failures[i] = b.DBValidator.Unique(ctx, check.table, check.column, check.value)
+				} else {
+					passed[i], failures[i] = b.DBValidator.Exists(ctx, check.table, check.column, check.value)
+				}
+			}
+		}()
+	}
+	for i := range checks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, failure := range failures {
+		if failure != nil {
+			return fmt.Errorf("%w: %w", ErrorStore, failure)
+		}
+	}
+
+	var errors, warnings Errors
+	for i, check := range checks {
+		if passed[i] {
+			options.report(check.model, check.field, check.rule, false)
+			continue
+		}
+		classification, message := ExistsError, "Exists"
+		if strings.HasPrefix(check.rule, "Unique(") {
+			classification, message = UniqueError, "Unique"
+		}
+		err := options.ruleError(check.model, check.field, check.name, check.rule, classification, message, check.soft)
+		if check.soft {
+			warnings = append(warnings, err)
+		} else {
+			errors = append(errors, err)
+		}
+	}
+	if len(warnings) > 0 && options.warn != nil {
+		options.warn(warnings)
+	}
+	if errors.Len() > 0 {
+		return errors
+	}
+	return nil
+}
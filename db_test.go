@@ -0,0 +1,156 @@
+package binding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type dbSuite struct{}
+
+var _ = Suite(&dbSuite{})
+
+type memoryStore struct {
+	rows map[string]bool
+	err  error
+}
+
+func (s *memoryStore) Unique(ctx context.Context, table, column string, value interface{}) (bool, error) {
+	return !s.rows[fmt.Sprintf("%s.%s=%v", table, column, value)], s.err
+}
+
+func (s *memoryStore) Exists(ctx context.Context, table, column string, value interface{}) (bool, error) {
+	return s.rows[fmt.Sprintf("%s.%s=%v", table, column, value)], s.err
+}
+
+type SignUp struct {
+	Email    string `json:"email" binding:"Required;Email;Unique(users.email)"`
+	Category *int   `json:"category" binding:"Exists(categories.id)"`
+	Referrer int64  `json:"referrer" binding:"Exists(users.id)"`
+}
+
+func (s *dbSuite) Test_UniqueAndExists(c *C) {
+	binder := &Binder{DBValidator: &memoryStore{rows: map[string]bool{"users.email=taken@example.com": true, "categories.id=3": true}}}
+
+	err := binder.Bind(&SignUp{}, newRequest(`POST`, ``, `{"email": "new@example.com", "category": 3}`, jsonContentType))
+	c.Assert(err, IsNil)
+
+	err = binder.Bind(&SignUp{}, newRequest(`POST`, ``, `{"email": "taken@example.com", "category": 4, "referrer": 7}`, jsonContentType))
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Email"}, Classification: UniqueError, Message: "Unique"},
+		{FieldNames: []string{"Category"}, Classification: ExistsError, Message: "Exists"},
+		{FieldNames: []string{"Referrer"}, Classification: ExistsError, Message: "Exists"},
+	})
+}
+
+func (s *dbSuite) Test_StoreSkippedForInvalidInput(c *C) {
+	binder := &Binder{DBValidator: &memoryStore{err: errors.New("store down")}}
+
+	err := binder.Bind(&SignUp{}, newRequest(`POST`, ``, `{"email": "not an email"}`, jsonContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Email"}, Classification: EmailError, Message: "Email"}})
+
+	err = binder.Bind(&SignUp{}, newRequest(`POST`, ``, `{"email": "new@example.com"}`, jsonContentType))
+	c.Assert(err, ErrorMatches, "Store error: store down")
+	c.Assert(errors.Is(err, ErrorStore), Equals, true)
+	c.Assert(StatusCode(err), Equals, http.StatusInternalServerError)
+	c.Assert(IsInvalid(err), Equals, false)
+}
+
+func (s *dbSuite) Test_WithoutDBValidator(c *C) {
+	err := Bind(&SignUp{}, newRequest(`POST`, ``, `{"email": "taken@example.com", "category": 4}`, jsonContentType))
+
+	c.Assert(err, IsNil)
+}
+
+type GuestList struct {
+	Guests []*SignUp `json:"guests"`
+}
+
+func (s *dbSuite) Test_UniqueAndExistsInSlices(c *C) {
+	binder := &Binder{DBValidator: &memoryStore{rows: map[string]bool{"users.email=taken@example.com": true}}}
+	err := binder.Bind(&GuestList{}, newRequest(`POST`, ``, `{"guests": [{"email": "new@example.com"}, {"email": "taken@example.com"}]}`, jsonContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Guests.1.Email"}, Classification: UniqueError, Message: "Unique"}})
+}
+
+type PublicProfile struct {
+	Nickname string `json:"nickname" binding:"?Unique(users.nickname)"`
+}
+
+func (s *dbSuite) Test_SoftUniqueWarns(c *C) {
+	binder := &Binder{DBValidator: &memoryStore{rows: map[string]bool{"users.nickname=jd": true}}}
+	stats := &Stats{}
+	err := binder.Bind(&PublicProfile{}, WithStats(newRequest(`POST`, ``, `{"nickname": "jd"}`, jsonContentType), stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(stats.Warnings, DeepEquals, Errors{{FieldNames: []string{"Nickname"}, Classification: UniqueError, Message: "Unique", Severity: SeverityWarning}})
+}
+
+// slowStore records the number of lookups running at the same time.
+type slowStore struct {
+	sync.Mutex
+	running, most int
+}
+
+func (s *slowStore) Unique(ctx context.Context, table, column string, value interface{}) (bool, error) {
+	s.Lock()
+	s.running++
+	if s.running > s.most {
+		s.most = s.running
+	}
+	s.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	s.Lock()
+	s.running--
+	s.Unlock()
+	return true, nil
+}
+
+func (s *slowStore) Exists(ctx context.Context, table, column string, value interface{}) (bool, error) {
+	return s.Unique(ctx, table, column, value)
+}
+
+func (s *dbSuite) Test_StoreLookupsBounded(c *C) {
+	store := &slowStore{}
+	guests := GuestList{}
+	for i := 0; i < 4*dbCheckWorkers; i++ {
+		guests.Guests = append(guests.Guests, &SignUp{Email: fmt.Sprintf("guest%d@example.com", i)})
+	}
+	binder := &Binder{DBValidator: store}
+
+	c.Assert(binder.validate(&guests, newRequest(`POST`, ``, ``, jsonContentType)), IsNil)
+	c.Assert(store.most <= dbCheckWorkers, Equals, true)
+	c.Assert(store.most > 1, Equals, true)
+}
+
+type Registration struct {
+	Email    string `json:"email" binding:"Unique(users.email)" errclass:"TakenError"`
+	Username string `json:"username" binding:"Unique(users.name)"`
+	Internal string `form:"-" binding:"Exists(users.id)"`
+}
+
+func (s *dbSuite) Test_StoreErrorsLikeOtherRules(c *C) {
+	var usages []RuleUsage
+	binder := &Binder{
+		DBValidator:    &memoryStore{rows: map[string]bool{"users.email=taken@example.com": true}},
+		WireFieldNames: true,
+		Provenance:     true,
+		OnRule:         func(usage RuleUsage) { usages = append(usages, usage) },
+	}
+	err := binder.Bind(&Registration{Internal: "7"}, newRequest(`POST`, ``, `{"email": "taken@example.com", "username": "jd"}`, jsonContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"email"}, Classification: "TakenError", Message: "Unique", Source: "rule:Unique(users.email) binding.Registration.Email"}})
+	c.Assert(usages, HasLen, 2)
+	c.Assert(usages[0].Field, Equals, "Email")
+	c.Assert(usages[0].Rule, Equals, "Unique(users.email)")
+	c.Assert(usages[0].Failed, Equals, true)
+	c.Assert(usages[1].Field, Equals, "Username")
+	c.Assert(usages[1].Failed, Equals, false)
+}
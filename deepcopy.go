@@ -0,0 +1,61 @@
+package binding
+
+import "reflect"
+
+// deepCopy returns a copy of v that shares no pointers, slices or maps with
+// v, so binding into the copy leaves v as it was. Unexported fields,
+// interfaces, functions, channels and time zones are copied as they are.
+// seen holds the copies of the pointers already followed, for cyclic values.
+func deepCopy(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Type() == locationType {
+			return v
+		}
+		if copied, ok := seen[v.Pointer()]; ok {
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		seen[v.Pointer()] = copied
+		copied.Elem().Set(deepCopy(v.Elem(), seen))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < copied.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if isBasicKind(v.Type().Elem().Kind()) {
+			reflect.Copy(copied, v)
+			return copied
+		}
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return copied
+	}
+	return v
+}
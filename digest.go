@@ -0,0 +1,128 @@
+package binding
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// digestAlgorithms are the Digest header algorithms (RFC 3230) the binder
+// verifies, strongest first.
+var digestAlgorithms = []struct {
+	name    string
+	newHash func() hash.Hash
+}{
+	{"sha-512", sha512.New},
+	{"sha-256", sha256.New},
+	{"sha", sha1.New},
+	{"md5", md5.New},
+}
+
+// digestReader hashes the request body as it is read and compares the hash
+// with the expected digest once the end of the body is reached.
+type digestReader struct {
+	io.ReadCloser
+	hash     hash.Hash
+	expected []byte
+	finished bool
+	mismatch bool
+}
+
+func (r *digestReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && !r.finished {
+		r.finished = true
+		r.mismatch = !bytes.Equal(r.hash.Sum(nil), r.expected)
+	}
+	if err == io.EOF && r.mismatch {
+		return n, ErrorIntegrity
+	}
+	return n, err
+}
+
+// Close reads the rest of the body, which the binding may have left unread,
+// so the digest covers all of it.
+func (r *digestReader) Close() error {
+	r.drain()
+	return r.ReadCloser.Close()
+}
+
+func (r *digestReader) drain() {
+	if !r.finished {
+		io.Copy(io.Discard, r)
+	}
+}
+
+// verifyDigest wraps the body of a request that carries a Digest or
+// Content-MD5 header in a digestReader. The headers are removed, so binding
+// the request again does not check the already consumed body twice.
+func (b *Binder) verifyDigest(req *http.Request) error {
+	if !b.VerifyDigest || req.Body == nil {
+		return nil
+	}
+
+	newHash, expected, ok := requestDigest(req.Header)
+	if !ok {
+		return nil
+	}
+	req.Header.Del("Digest")
+	req.Header.Del("Content-MD5")
+	if expected == nil {
+		return ErrorIntegrity
+	}
+
+	reader := &digestReader{ReadCloser: req.Body, hash: newHash(), expected: expected}
+	req.Body = reader
+	addBodyCheck(req, reader)
+	return nil
+}
+
+// finish completes the digest check once the binding is done with the body.
+// The rest of the body is only read when the binding succeeded or failed
+// validation; a mismatch in the part that was read replaces any other error.
+func (r *digestReader) finish(err error) error {
+	if err == nil || IsInvalid(err) {
+		r.drain()
+	}
+	if r.mismatch {
+		return ErrorIntegrity
+	}
+	return err
+}
+
+// requestDigest returns the hash and the expected digest of the strongest
+// algorithm in the Digest header, or of the Content-MD5 header. The digest is
+// nil when it is not valid base64.
+func requestDigest(header http.Header) (func() hash.Hash, []byte, bool) {
+	digests := map[string]string{}
+	for _, digest := range strings.Split(header.Get("Digest"), ",") {
+		parts := strings.SplitN(strings.TrimSpace(digest), "=", 2)
+		if len(parts) == 2 {
+			digests[strings.ToLower(parts[0])] = parts[1]
+		}
+	}
+	if md5Digest := header.Get("Content-MD5"); md5Digest != "" {
+		if _, exists := digests["md5"]; !exists {
+			digests["md5"] = strings.TrimSpace(md5Digest)
+		}
+	}
+
+	for _, algorithm := range digestAlgorithms {
+		if encoded, exists := digests[algorithm.name]; exists {
+			expected, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				expected = nil
+			}
+			return algorithm.newHash, expected, true
+		}
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,96 @@
+package binding
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type digestSuite struct{}
+
+var _ = Suite(&digestSuite{})
+
+func sha256Digest(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return "sha-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (s *digestSuite) Test_DigestMatches(c *C) {
+	body := `{"title": "Glorious Post Title"}`
+	req := newRequest(`POST`, ``, body, jsonContentType)
+	req.Header.Set("Digest", "unixsum=30637, "+sha256Digest(body))
+	binder := &Binder{VerifyDigest: true}
+	post := Post{}
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
+
+func (s *digestSuite) Test_DigestMismatch(c *C) {
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.Header.Set("Digest", sha256Digest(`{"title": "Other"}`))
+	binder := &Binder{VerifyDigest: true}
+	err := binder.Bind(&Post{}, req)
+
+	c.Assert(err, Equals, ErrorIntegrity)
+	c.Assert(StatusCode(err), Equals, http.StatusBadRequest)
+}
+
+func (s *digestSuite) Test_DigestCoversUnreadBody(c *C) {
+	body := `{"title": "Glorious Post Title"}` + "\n\n"
+	req := newRequest(`POST`, ``, body, jsonContentType)
+	req.Header.Set("Digest", sha256Digest(`{"title": "Glorious Post Title"}`))
+	binder := &Binder{VerifyDigest: true}
+	err := binder.Bind(&Post{}, req)
+
+	c.Assert(err, Equals, ErrorIntegrity)
+}
+
+func (s *digestSuite) Test_ContentMD5(c *C) {
+	body := `title=Glorious+Post+Title`
+	sum := md5.Sum([]byte(body))
+	req := newRequest(`POST`, ``, body, formContentType)
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	binder := &Binder{VerifyDigest: true}
+	post := Post{}
+
+	c.Assert(binder.Bind(&post, req), IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+
+	req = newRequest(`POST`, ``, `title=Tampered`, formContentType)
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+
+	c.Assert(binder.Bind(&post, req), Equals, ErrorIntegrity)
+}
+
+func (s *digestSuite) Test_MalformedDigest(c *C) {
+	req := newRequest(`POST`, ``, `{}`, jsonContentType)
+	req.Header.Set("Digest", "sha-256=not base64")
+	binder := &Binder{VerifyDigest: true}
+
+	c.Assert(binder.Bind(&Post{}, req), Equals, ErrorIntegrity)
+}
+
+func (s *digestSuite) Test_DigestIgnoredWithoutOption(c *C) {
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.Header.Set("Digest", sha256Digest(`{"title": "Other"}`))
+
+	c.Assert(Bind(&Post{}, req), IsNil)
+}
+
+func (s *digestSuite) Test_DigestOfCompressedBody(c *C) {
+	body := gzipped(`{"title": "Glorious Post Title"}`)
+	req := newRequest(`POST`, ``, body, jsonContentType)
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Digest", sha256Digest(body))
+	binder := &Binder{VerifyDigest: true}
+	post := Post{}
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
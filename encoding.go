@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"encoding"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var (
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	bytesType             = reflect.TypeOf([]byte(nil))
+)
+
+// decodeBinary decodes a string value with the named encoding; "base64"
+// (the default), "base64url", "hex" or "base32".
+func decodeBinary(name, val string) ([]byte, error) {
+	switch name {
+	case "", "base64":
+		return base64.RawStdEncoding.DecodeString(strings.TrimRight(val, "="))
+	case "base64url":
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(val, "="))
+	case "hex":
+		return hex.DecodeString(val)
+	case "base32":
+		return base32.StdEncoding.DecodeString(val)
+	}
+	return nil, errors.New("unknown encoding " + name)
+}
+
+// isBinaryUnmarshaler reports whether a field of type typ, or its pointer,
+// implements encoding.BinaryUnmarshaler.
+func isBinaryUnmarshaler(typ reflect.Type) bool {
+	return typ != timeType && typ != reflect.PtrTo(timeType) &&
+		(typ.Implements(binaryUnmarshalerType) || reflect.PtrTo(typ).Implements(binaryUnmarshalerType))
+}
+
+// binaryEncoding returns the encoding of binary values for the field, from
+// its encoding tag or the binder.
+func (m *formMapper) binaryEncoding(field reflect.StructField) string {
+	if name := field.Tag.Get("encoding"); name != "" {
+		return name
+	}
+	return m.binder.BinaryEncoding
+}
+
+// setBinary passes data to the UnmarshalBinary method of the field. A pointer
+// field is only allocated when data unmarshals.
+func setBinary(data []byte, structField reflect.Value) error {
+	target := structField
+	if structField.Kind() == reflect.Ptr {
+		target = reflect.New(structField.Type().Elem())
+	} else {
+		target = structField.Addr()
+	}
+
+	if err := target.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+		return err
+	}
+
+	if structField.Kind() == reflect.Ptr {
+		structField.Set(target)
+	}
+	return nil
+}
+
+// isTextUnmarshaler reports whether a field of type typ, or the type it points
+// to, implements encoding.TextUnmarshaler. time.Time and the value types are
+// parsed by the binder itself.
+func isTextUnmarshaler(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if _, ok := lookupValueType(typ); ok || typ == timeType {
+		return false
+	}
+	return reflect.PtrTo(typ).Implements(textUnmarshalerType)
+}
+
+// setText passes val to the UnmarshalText method of the field. A pointer field
+// is only allocated when val unmarshals.
+func setText(val string, structField reflect.Value) error {
+	typ := structField.Type()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	target := reflect.New(typ)
+	if err := target.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val)); err != nil {
+		return err
+	}
+
+	if structField.Kind() == reflect.Ptr {
+		structField.Set(target)
+	} else {
+		structField.Set(target.Elem())
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package binding
+
+import (
+	"errors"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type encodingSuite struct{}
+
+var _ = Suite(&encodingSuite{})
+
+// Checksum is a fixed size value implementing encoding.BinaryUnmarshaler.
+type Checksum [4]byte
+
+func (c *Checksum) UnmarshalBinary(data []byte) error {
+	if len(data) != len(c) {
+		return errors.New("invalid checksum length")
+	}
+	copy(c[:], data)
+	return nil
+}
+
+type Download struct {
+	Checksum Checksum  `form:"checksum"`
+	Hex      *Checksum `form:"hex" encoding:"hex"`
+}
+
+func (s *encodingSuite) Test_BinaryUnmarshalerBase64(c *C) {
+	download := Download{}
+	req := newRequest(`GET`, `?checksum=3q2%2B7w%3D%3D&hex=deadbeef`, ``, formContentType)
+	err := Form.Bind(&download, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(download.Checksum, Equals, Checksum{0xde, 0xad, 0xbe, 0xef})
+	c.Assert(download.Hex, NotNil)
+	c.Assert(*download.Hex, Equals, Checksum{0xde, 0xad, 0xbe, 0xef})
+}
+
+func (s *encodingSuite) Test_BinaryUnmarshalerBinderEncoding(c *C) {
+	download := Download{}
+	req := newRequest(`GET`, `?checksum=deadbeef`, ``, formContentType)
+	binder := &Binder{BinaryEncoding: "hex"}
+	err := binder.Form().Bind(&download, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(download.Checksum, Equals, Checksum{0xde, 0xad, 0xbe, 0xef})
+}
+
+func (s *encodingSuite) Test_BinaryUnmarshalerInvalidValue(c *C) {
+	download := Download{}
+	req := newRequest(`GET`, `?checksum=not+base64`, ``, formContentType)
+	err := Form.Bind(&download, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"checksum"}, Classification: EncodingError, Message: "Invalid encoding"}})
+	c.Assert(download, DeepEquals, Download{})
+
+	download = Download{}
+	req = newRequest(`GET`, `?hex=dead`, ``, formContentType)
+	err = Form.Bind(&download, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"hex"}, Classification: DeserializationError, Message: "Malformed value"}})
+	c.Assert(download, DeepEquals, Download{})
+}
+
+type SignedForm struct {
+	Signature []byte `form:"signature" encoding:"hex" binding:"Length(4)"`
+	Token     []byte `form:"token" encoding:"base32"`
+}
+
+func (s *encodingSuite) Test_EncodedBytes(c *C) {
+	signed := SignedForm{}
+	req := newRequest(`POST`, ``, `signature=DEADBEEF&token=MZXW6%3D%3D%3D`, formContentType)
+	err := Form.Bind(&signed, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(signed, DeepEquals, SignedForm{Signature: []byte{0xde, 0xad, 0xbe, 0xef}, Token: []byte("foo")})
+}
+
+func (s *encodingSuite) Test_EncodedBytesLengthOfDecodedValue(c *C) {
+	signed := SignedForm{}
+	req := newRequest(`POST`, ``, `signature=deadbe`, formContentType)
+	err := Form.Bind(&signed, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Signature"}, Classification: LengthError, Message: "Length"}})
+	c.Assert(signed.Signature, DeepEquals, []byte{0xde, 0xad, 0xbe})
+}
+
+// Level is an enum implementing encoding.TextUnmarshaler.
+type Level int
+
+func (l *Level) UnmarshalText(text []byte) error {
+	for i, name := range []string{"debug", "info", "error"} {
+		if strings.EqualFold(string(text), name) {
+			*l = Level(i)
+			return nil
+		}
+	}
+	return errors.New("unknown level")
+}
+
+type LogFilter struct {
+	Level   Level    `form:"level" query:"level"`
+	Minimum *Level   `form:"minimum"`
+	Levels  []Level  `form:"levels"`
+	Unset   *Level   `form:"unset"`
+	Tags    []string `form:"tag"`
+}
+
+func (s *encodingSuite) Test_TextUnmarshaler(c *C) {
+	filter := LogFilter{}
+	req := newRequest(`POST`, ``, `level=error&minimum=INFO&levels=debug&levels=error&tag=api`, formContentType)
+	err := Form.Bind(&filter, req)
+
+	minimum := Level(1)
+	c.Assert(err, IsNil)
+	c.Assert(filter, DeepEquals, LogFilter{Level: 2, Minimum: &minimum, Levels: []Level{0, 2}, Tags: []string{"api"}})
+
+	filter = LogFilter{}
+	err = Query(&filter, newRequest(`GET`, `/logs?level=info`, ``, ``))
+	c.Assert(err, IsNil)
+	c.Assert(filter.Level, Equals, Level(1))
+}
+
+func (s *encodingSuite) Test_TextUnmarshalerInvalidValue(c *C) {
+	filter := LogFilter{}
+	err := Form.Bind(&filter, newRequest(`POST`, ``, `minimum=verbose`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"minimum"}, Classification: DeserializationError, Message: "Malformed value"}})
+	c.Assert(filter.Minimum, IsNil)
+}
+
+type WebhookDelivery struct {
+	Payload   []byte `form:"payload"`
+	Signature []byte `form:"signature" query:"signature" encoding:"base64url"`
+}
+
+func (s *encodingSuite) Test_Base64Bytes(c *C) {
+	delivery := WebhookDelivery{}
+	req := newRequest(`POST`, `/hooks?signature=_-8`, `payload=eyJpZCI6MX0%3D`, formContentType)
+	err := Bind(&delivery, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(delivery, DeepEquals, WebhookDelivery{Payload: []byte(`{"id":1}`), Signature: []byte{0xff, 0xef}})
+
+	delivery = WebhookDelivery{}
+	err = Form.Bind(&delivery, newRequest(`POST`, ``, `payload=eyJpZCI6MX0&signature=_-8%3D`, formContentType))
+	c.Assert(err, IsNil)
+	c.Assert(delivery, DeepEquals, WebhookDelivery{Payload: []byte(`{"id":1}`), Signature: []byte{0xff, 0xef}})
+}
+
+func (s *encodingSuite) Test_Base64BytesInvalidValue(c *C) {
+	err := Form.Bind(&WebhookDelivery{}, newRequest(`POST`, ``, `signature=not+base64!`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"signature"}, Classification: EncodingError, Message: "Invalid encoding"}})
+}
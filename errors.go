@@ -0,0 +1,389 @@
+package binding
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	RequiredError        = "RequiredError"
+	RequiredFileError    = "RequiredFileError"
+	AlphaDashError       = "AlphaDashError"
+	AlphaDashDotError    = "AlphaDashDotError"
+	MinSizeError         = "MinSizeError"
+	MaxSizeError         = "MaxSizeError"
+	LengthError          = "LengthError"
+	MinDurationError     = "MinDurationError"
+	MaxDurationError     = "MaxDurationError"
+	DecimalError         = "DecimalError"
+	EmailError           = "EmailError"
+	UrlError             = "UrlError"
+	RangeError           = "RangeError"
+	InError              = "InError"
+	NotInError           = "NotInError"
+	IncludeError         = "IncludeError"
+	ExcludeError         = "ExcludeError"
+	DefaultError         = "DefaultError"
+	LatitudeError        = "LatitudeError"
+	LongitudeError       = "LongitudeError"
+	ColorError           = "ColorError"
+	LocaleError          = "LocaleError"
+	TimezoneError        = "TimezoneError"
+	IPError              = "IPError"
+	CIDRError            = "CIDRError"
+	UniqueError          = "UniqueError"
+	ExistsError          = "ExistsError"
+	BeforeError          = "BeforeError"
+	AfterError           = "AfterError"
+	UnknownPartError     = "UnknownPartError"
+	MultipleValuesError  = "MultipleValuesError"
+	DeprecatedError      = "DeprecatedError"
+	DeserializationError = "DeserializationError"
+	IntegerTypeError     = "IntegerTypeError"
+	BooleanTypeError     = "BooleanTypeError"
+	FloatTypeError       = "FloatTypeError"
+	UnitError            = "UnitError"
+	TimeTypeError        = "TimeTypeError"
+	DurationError        = "DurationError"
+	EncodingError        = "EncodingError"
+	ContentTypeError     = "ContentTypeError"
+	RequestTooLargeError = "RequestTooLargeError"
+	LimitExceededError   = "LimitExceededError"
+	ReplayError          = "ReplayError"
+	CsrfError            = "CsrfError"
+	SpamError            = "SpamError"
+	PatchError           = "PatchError"
+	IntegrityError       = "IntegrityError"
+	ContentLengthError   = "ContentLengthError"
+	LengthRequiredError  = "LengthRequiredError"
+	ExampleError         = "ExampleError"
+	UnbindableError      = "UnbindableError"
+
+	// VersionError is the classification of ErrorUnsupportedVersion.
+	VersionError = "VersionError"
+
+	// TooManyInvalidRecordsError is the classification of ErrorTooManyInvalidRecords.
+	TooManyInvalidRecordsError = "TooManyInvalidRecordsError"
+
+	// InternalError classifies the errors caused by the caller of a binding
+	// instead of by the request, like a model that is not passed by reference,
+	// a nil request or a failing DBValidator.
+	InternalError = "InternalError"
+)
+
+// Severities of an Error; entries with a warning severity describe
+// issues that did not fail the binding.
+const (
+	SeverityError   = ""
+	SeverityWarning = "warning"
+)
+
+// FieldMessages holds the messages ToFieldMessages uses for the
+// classifications of the rules; other errors keep their own message.
+var FieldMessages = map[string]string{
+	RequiredError:        "is required",
+	AlphaDashError:       "may only contain letters, digits, dashes and underscores",
+	AlphaDashDotError:    "may only contain letters, digits, dashes, underscores and dots",
+	MinSizeError:         "is too short",
+	MaxSizeError:         "is too long",
+	LengthError:          "has the wrong length",
+	MinDurationError:     "is too short",
+	MaxDurationError:     "is too long",
+	DecimalError:         "is not a valid decimal",
+	EmailError:           "is not a valid email address",
+	UrlError:             "is not a valid url",
+	RangeError:           "is out of range",
+	InError:              "is not one of the allowed values",
+	NotInError:           "is not allowed",
+	IncludeError:         "does not contain the required text",
+	ExcludeError:         "contains text that is not allowed",
+	LatitudeError:        "is not a valid latitude",
+	LongitudeError:       "is not a valid longitude",
+	ColorError:           "is not a valid color",
+	LocaleError:          "is not a valid locale",
+	TimezoneError:        "is not a valid time zone",
+	IPError:              "is not a valid IP address",
+	CIDRError:            "is not a valid network",
+	UniqueError:          "is already taken",
+	ExistsError:          "does not exist",
+	BeforeError:          "is too late",
+	AfterError:           "is too early",
+	DeserializationError: "is malformed",
+	RequiredFileError:    "is required",
+	IntegerTypeError:     "is not a whole number",
+	BooleanTypeError:     "is not a boolean",
+	FloatTypeError:       "is not a number",
+	UnitError:            "does not have a valid unit",
+	TimeTypeError:        "is not a valid time",
+	DurationError:        "is not a valid duration",
+	EncodingError:        "is not validly encoded",
+}
+
+type (
+	// Errors may be generated during deserialization or validation
+	// of a bound structure. Errors satisfies the error interface, so it
+	// can be returned from the binders like any other error.
+	Errors []Error
+
+	// Error describes a single validation failure on one or more fields.
+	Error struct {
+		// An error supports zero or more field names, because an
+		// error can morph three ways: (1) it can indicate something
+		// wrong with the request as a whole, (2) it can point to a
+		// specific problem with a particular input field, or (3) it
+		// can span multiple related input fields.
+		FieldNames []string `json:"fieldNames,omitempty"`
+
+		// The classification is like an error code, convenient to
+		// use when processing or categorizing an error programmatically.
+		Classification string `json:"classification,omitempty"`
+
+		// Message should be human-readable and detailed enough to
+		// pinpoint and resolve the problem.
+		Message string `json:"message,omitempty"`
+
+		// Severity is empty for errors and SeverityWarning for warnings.
+		Severity string `json:"severity,omitempty"`
+
+		// Source names the stage that produced the error, when the
+		// Provenance option of the binder is set.
+		Source string `json:"source,omitempty"`
+	}
+)
+
+// Add adds an error associated with the fields indicated
+// by fieldNames, with the given classification and message.
+func (e *Errors) Add(fieldNames []string, classification, message string) {
+	*e = append(*e, Error{
+		FieldNames:     fieldNames,
+		Classification: classification,
+		Message:        message,
+	})
+}
+
+// Addf adds an error associated with the fields indicated by fieldNames,
+// with the given classification and a message formatted like fmt.Sprintf.
+func (e *Errors) Addf(fieldNames []string, classification, format string, args ...interface{}) {
+	e.Add(fieldNames, classification, fmt.Sprintf(format, args...))
+}
+
+// AddRequired adds a RequiredError for the field, shaped like the errors of
+// the Required rule.
+func (e *Errors) AddRequired(field string) {
+	*e = append(*e, NewFieldError(field, RequiredError, "Required"))
+}
+
+// NewFieldError returns an error on a single field with the given
+// classification and message.
+func NewFieldError(field, classification, message string) Error {
+	return Error{
+		FieldNames:     []string{field},
+		Classification: classification,
+		Message:        message,
+	}
+}
+
+// ToFieldMessages groups the messages of the errors by field name, in the
+// shape most frontend form libraries expect: {"title": ["is required"]}.
+// Errors without field names are listed under the empty name. Bind with the
+// WireFieldNames option to get the names of the posted inputs.
+func (e Errors) ToFieldMessages() map[string][]string {
+	messages := map[string][]string{}
+	for _, err := range e {
+		message, ok := FieldMessages[err.Classification]
+		if !ok {
+			message = err.Message
+		}
+
+		fields := err.FieldNames
+		if len(fields) == 0 {
+			fields = []string{""}
+		}
+		for _, field := range fields {
+			messages[field] = append(messages[field], message)
+		}
+	}
+	return messages
+}
+
+// MarshalXML renders the errors as a stable document for clients that only
+// speak XML; the field names are joined by commas and the message is the text:
+//
+//	<errors><error field="title" class="RequiredError">Required</error></errors>
+func (e Errors) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "errors"}}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, err := range e {
+		element := xml.StartElement{Name: xml.Name{Local: "error"}}
+		if len(err.FieldNames) > 0 {
+			element.Attr = append(element.Attr, xml.Attr{Name: xml.Name{Local: "field"}, Value: strings.Join(err.FieldNames, ",")})
+		}
+		if err.Classification != "" {
+			element.Attr = append(element.Attr, xml.Attr{Name: xml.Name{Local: "class"}, Value: err.Classification})
+		}
+		if err.Severity != "" {
+			element.Attr = append(element.Attr, xml.Attr{Name: xml.Name{Local: "severity"}, Value: err.Severity})
+		}
+		if err.Source != "" {
+			element.Attr = append(element.Attr, xml.Attr{Name: xml.Name{Local: "source"}, Value: err.Source})
+		}
+		if err := encoder.EncodeElement(err.Message, element); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// Len returns the number of errors.
+func (e Errors) Len() int {
+	return len(e)
+}
+
+// Has determines whether an Errors slice has an Error with
+// a given classification in it; it does not search on messages
+// or field names.
+func (e Errors) Has(class string) bool {
+	for _, err := range e {
+		if err.Kind() == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Error returns a concatenation of all its error messages.
+func (e Errors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Fields returns the list of field names this error is
+// associated with.
+func (e Error) Fields() []string {
+	return e.FieldNames
+}
+
+// Kind returns this error's classification.
+func (e Error) Kind() string {
+	return e.Classification
+}
+
+// Error returns this error's message.
+func (e Error) Error() string {
+	return e.Message
+}
+
+// Status returns the HTTP status code suggested for these errors: 500 for
+// internal and unclassified errors, 415 for content type errors, 413 for
+// oversized requests or exceeded limits, 411 for a missing Content-Length,
+// 400 for malformed bodies, failed integrity checks, a wrong Content-Length or
+// an unsupported model version, 403 for rejected CSRF tokens and 422 for any
+// other (validation) error.
+func (e Errors) Status() int {
+	switch {
+	case e.Len() == 0:
+		return http.StatusOK
+	case e.internal():
+		return http.StatusInternalServerError
+	case e.Has(ContentTypeError):
+		return http.StatusUnsupportedMediaType
+	case e.Has(RequestTooLargeError), e.Has(LimitExceededError):
+		return http.StatusRequestEntityTooLarge
+	case e.Has(LengthRequiredError):
+		return http.StatusLengthRequired
+	case e.Has(DeserializationError), e.Has(IntegrityError), e.Has(ContentLengthError), e.Has(VersionError):
+		return http.StatusBadRequest
+	case e.Has(CsrfError):
+		return http.StatusForbidden
+	}
+	return http.StatusUnprocessableEntity
+}
+
+// Malformed reports whether the errors mean the payload could not be
+// parsed at all, as opposed to being parsed but failing validation.
+func (e Errors) Malformed() bool {
+	return e.Has(DeserializationError) || e.Has(ContentTypeError) || e.Has(RequestTooLargeError) || e.Has(IntegrityError) ||
+		e.Has(ContentLengthError) || e.Has(LengthRequiredError)
+}
+
+// internal reports whether the errors hold an InternalError or an error
+// without classification, which the request itself did not cause.
+func (e Errors) internal() bool {
+	for _, err := range e {
+		if err.Classification == InternalError || (err.Classification == "" && err.Severity == SeverityError) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMalformed reports whether an error returned by a binding means the
+// payload could not be parsed.
+func IsMalformed(err error) bool {
+	return toErrors(err).Malformed()
+}
+
+// IsInvalid reports whether an error returned by a binding means the payload
+// was parsed but failed validation.
+func IsInvalid(err error) bool {
+	errors := toErrors(err)
+	return errors.Len() > 0 && !errors.Malformed() && !errors.internal()
+}
+
+// StatusCode returns the HTTP status code suggested for an error returned
+// by one of the bindings.
+func StatusCode(err error) int {
+	return toErrors(err).Status()
+}
+
+// errorClassifications classifies the package level errors.
+var errorClassifications = []struct {
+	err            error
+	classification string
+}{
+	{ErrorDeserialization, DeserializationError},
+	{ErrorEmptyContentType, ContentTypeError},
+	{ErrorUnsupportedContentType, ContentTypeError},
+	{ErrorRequestTooLarge, RequestTooLargeError},
+	{ErrorIntegrity, IntegrityError},
+	{ErrorContentLength, ContentLengthError},
+	{ErrorLengthRequired, LengthRequiredError},
+	{ErrorUnsupportedVersion, VersionError},
+	{ErrorTooManyInvalidRecords, TooManyInvalidRecordsError},
+	{ErrorInputNotByReference, InternalError},
+	{ErrorInputIsNotStructure, InternalError},
+	{ErrorInputIsNilPointer, InternalError},
+	{ErrorInputIsNotSlice, InternalError},
+	{ErrorNilRequest, InternalError},
+	{ErrorNilResponse, InternalError},
+	{ErrorStore, InternalError},
+}
+
+// toErrors converts an error returned by a binding into Errors, classifying
+// the package level errors. Other errors, like those returned by CheckHeaders,
+// are left without classification.
+func toErrors(err error) Errors {
+	if err == nil {
+		return nil
+	}
+
+	if errors, ok := err.(Errors); ok {
+		return errors
+	}
+
+	for _, known := range errorClassifications {
+		if errors.Is(err, known.err) {
+			return Errors{Error{Classification: known.classification, Message: err.Error()}}
+		}
+	}
+	return Errors{Error{Message: err.Error()}}
+}
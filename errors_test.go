@@ -0,0 +1,115 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type errorsSuite struct{}
+
+var _ = Suite(&errorsSuite{})
+
+func (s *errorsSuite) Test_Status(c *C) {
+	c.Assert(Errors{}.Status(), Equals, http.StatusOK)
+	c.Assert(Errors{{Classification: RequiredError}}.Status(), Equals, http.StatusUnprocessableEntity)
+	c.Assert(Errors{{Classification: RequiredError}, {Classification: DeserializationError}}.Status(), Equals, http.StatusBadRequest)
+	c.Assert(Errors{{Classification: RequestTooLargeError}}.Status(), Equals, http.StatusRequestEntityTooLarge)
+	c.Assert(Errors{{Classification: ContentTypeError}}.Status(), Equals, http.StatusUnsupportedMediaType)
+}
+
+func (s *errorsSuite) Test_StatusCode(c *C) {
+	c.Assert(StatusCode(nil), Equals, http.StatusOK)
+	c.Assert(StatusCode(ErrorDeserialization), Equals, http.StatusBadRequest)
+	c.Assert(StatusCode(ErrorEmptyContentType), Equals, http.StatusUnsupportedMediaType)
+	c.Assert(StatusCode(ErrorUnsupportedContentType), Equals, http.StatusUnsupportedMediaType)
+	c.Assert(StatusCode(ErrorRequestTooLarge), Equals, http.StatusRequestEntityTooLarge)
+	c.Assert(StatusCode(Errors{{Classification: MinSizeError}}), Equals, http.StatusUnprocessableEntity)
+}
+
+func (s *errorsSuite) Test_StatusCodeOfInternalErrors(c *C) {
+	c.Assert(StatusCode(ErrorInputNotByReference), Equals, http.StatusInternalServerError)
+	c.Assert(StatusCode(ErrorNilRequest), Equals, http.StatusInternalServerError)
+	c.Assert(StatusCode(fmt.Errorf("store: %w", io.ErrUnexpectedEOF)), Equals, http.StatusInternalServerError)
+	c.Assert(StatusCode(fmt.Errorf("decoding: %w", ErrorDeserialization)), Equals, http.StatusBadRequest)
+	c.Assert(StatusCode(ErrorUnsupportedVersion), Equals, http.StatusBadRequest)
+	c.Assert(StatusCode(ErrorTooManyInvalidRecords), Equals, http.StatusUnprocessableEntity)
+
+	for _, err := range []error{ErrorNilRequest, ErrorInputIsNotStructure, errors.New("connection refused")} {
+		c.Assert(IsMalformed(err), Equals, false)
+		c.Assert(IsInvalid(err), Equals, false)
+	}
+	c.Assert(toErrors(errors.New("connection refused")), DeepEquals, Errors{{Message: "connection refused"}})
+}
+
+func (s *errorsSuite) Test_MalformedAndInvalid(c *C) {
+	c.Assert(IsMalformed(nil), Equals, false)
+	c.Assert(IsInvalid(nil), Equals, false)
+
+	c.Assert(IsMalformed(ErrorDeserialization), Equals, true)
+	c.Assert(IsInvalid(ErrorDeserialization), Equals, false)
+
+	c.Assert(IsMalformed(ErrorUnsupportedContentType), Equals, true)
+	c.Assert(IsMalformed(ErrorRequestTooLarge), Equals, true)
+
+	validation := Errors{{FieldNames: []string{"Title"}, Classification: RequiredError}}
+	c.Assert(IsMalformed(validation), Equals, false)
+	c.Assert(IsInvalid(validation), Equals, true)
+	c.Assert(validation.Malformed(), Equals, false)
+}
+
+func (s *errorsSuite) Test_MalformedFromBinding(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title":"foo"`, jsonContentType)
+	err := JSON.Bind(&post, req)
+
+	c.Assert(IsMalformed(err), Equals, true)
+	c.Assert(IsInvalid(err), Equals, false)
+}
+
+func (s *errorsSuite) Test_Helpers(c *C) {
+	var errs Errors
+	errs.AddRequired("Title")
+	errs.Addf([]string{"Start", "End"}, RangeError, "End must be %d days after start", 2)
+	errs = append(errs, NewFieldError("Email", EmailError, "Email"))
+
+	c.Assert(errs, DeepEquals, Errors{
+		{FieldNames: []string{"Title"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Start", "End"}, Classification: RangeError, Message: "End must be 2 days after start"},
+		{FieldNames: []string{"Email"}, Classification: EmailError, Message: "Email"},
+	})
+}
+
+func (s *errorsSuite) Test_AddRequiredMatchesRule(c *C) {
+	var errs Errors
+	errs.AddRequired("Name")
+
+	c.Assert(Validate(&RequiredName{}), DeepEquals, errs)
+}
+
+type SignupForm struct {
+	Title   string `form:"title" binding:"Required;MinSize(3)"`
+	Email   string `json:"email" binding:"Email"`
+	Country string `binding:"Length(2)"`
+}
+
+func (s *errorsSuite) Test_ToFieldMessages(c *C) {
+	signup := SignupForm{Email: "invalid", Country: "NLD"}
+	binder := &Binder{WireFieldNames: true}
+	err := binder.Form().Bind(&signup, newRequest(`POST`, ``, `email=invalid&Country=NLD`, formContentType))
+
+	c.Assert(toErrors(err).ToFieldMessages(), DeepEquals, map[string][]string{
+		"title":   {"is required", "is too short"},
+		"email":   {"is not a valid email address"},
+		"Country": {"has the wrong length"},
+	})
+}
+
+func (s *errorsSuite) Test_ToFieldMessagesWithoutFields(c *C) {
+	errs := Errors{{Classification: CsrfError, Message: "Invalid CSRF token"}}
+
+	c.Assert(errs.ToFieldMessages(), DeepEquals, map[string][]string{"": {"Invalid CSRF token"}})
+}
@@ -0,0 +1,77 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// ExampleProvider is implemented by models that document themselves with
+// example values. Each example is a value of the model type, or a pointer to
+// one, that binds and validates; documentation generators can show them and
+// CheckExamples verifies them in tests.
+type ExampleProvider interface {
+	BindingExamples() []interface{}
+}
+
+// CheckExamples round-trips the examples of the model through the JSON
+// binding of the default binder.
+func CheckExamples(model ExampleProvider) Errors {
+	return defaultBinder.CheckExamples(model)
+}
+
+// CheckExamples encodes every example of the model as JSON, binds it into a
+// new value of the example type and returns the problems found: validation
+// errors, errors of the binding, and an ExampleError for examples that do not
+// bind back into an equal value, for example because of fields hidden from
+// JSON. The index of the example prefixes the field names of the errors, or is
+// the field name of errors without one. The result is nil when every example
+// round-trips.
+func (b *Binder) CheckExamples(model ExampleProvider) Errors {
+	var errors Errors
+	for i, example := range model.BindingExamples() {
+		index := strconv.Itoa(i)
+		typ := reflect.TypeOf(example)
+		expected := reflect.ValueOf(example)
+		if typ == nil || (typ.Kind() == reflect.Ptr && expected.IsNil()) {
+			errors.Add([]string{index}, ExampleError, "Example is nil")
+			continue
+		}
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+			expected = expected.Elem()
+		}
+
+		data, err := json.Marshal(example)
+		if err != nil {
+			errors.Add([]string{index}, ExampleError, err.Error())
+			continue
+		}
+
+		req, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+		req.Header.Set("Content-Type", MIMEJSON)
+		dst := reflect.New(typ)
+		err = b.JSON().Bind(dst.Interface(), req)
+		if err != nil {
+			for _, bindError := range toErrors(err) {
+				fieldNames := []string{index}
+				if len(bindError.FieldNames) > 0 {
+					fieldNames = make([]string, len(bindError.FieldNames))
+					for j, name := range bindError.FieldNames {
+						fieldNames[j] = index + "." + name
+					}
+				}
+				bindError.FieldNames = fieldNames
+				errors = append(errors, bindError)
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(dst.Elem().Interface(), expected.Interface()) {
+			errors.Add([]string{index}, ExampleError, "Example does not round-trip")
+		}
+	}
+	return errors
+}
@@ -0,0 +1,47 @@
+package binding
+
+import . "gopkg.in/check.v1"
+
+type examplesSuite struct{}
+
+var _ = Suite(&examplesSuite{})
+
+type DocumentedPost struct {
+	Title   string `json:"title" binding:"Required"`
+	Content string `json:"content"`
+	Draft   bool   `json:"-"`
+}
+
+type documentedPosts []interface{}
+
+func (e documentedPosts) BindingExamples() []interface{} {
+	return e
+}
+
+func (s *examplesSuite) Test_ExamplesRoundTrip(c *C) {
+	examples := documentedPosts{DocumentedPost{Title: "Glorious"}, &DocumentedPost{Title: "Post", Content: "Lorem ipsum"}}
+
+	c.Assert(CheckExamples(examples), IsNil)
+}
+
+func (s *examplesSuite) Test_ExampleProblems(c *C) {
+	examples := documentedPosts{
+		DocumentedPost{Title: "Glorious"},
+		DocumentedPost{Content: "Lorem ipsum"},
+		DocumentedPost{Title: "Draft", Draft: true},
+		nil,
+	}
+
+	c.Assert(CheckExamples(examples), DeepEquals, Errors{
+		{FieldNames: []string{"1.Title"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"2"}, Classification: ExampleError, Message: "Example does not round-trip"},
+		{FieldNames: []string{"3"}, Classification: ExampleError, Message: "Example is nil"},
+	})
+}
+
+func (s *examplesSuite) Test_ExampleBindingError(c *C) {
+	binder := &Binder{Limits: Limits{MaxKeys: 1}}
+	errs := binder.CheckExamples(documentedPosts{DocumentedPost{Title: "Glorious"}})
+
+	c.Assert(errs, DeepEquals, Errors{{FieldNames: []string{"0"}, Classification: LimitExceededError, Message: "MaxKeys exceeded"}})
+}
@@ -0,0 +1,80 @@
+package binding
+
+import (
+	"net"
+	"net/http"
+	"reflect"
+)
+
+// Failure describes a failed binding, as passed to the OnFailure callback of a binder.
+type Failure struct {
+	// Classification is the classification that selected the failure
+	Classification string
+
+	// ClientIP is the host of the remote address of the request
+	ClientIP string
+
+	// Model is the type of the value the request was bound to
+	Model reflect.Type
+
+	// Err is the error returned by the binding
+	Err error
+}
+
+// defaultFailureClassifications are the classifications reported to the
+// OnFailure callback when the binder does not list its own; the failures that
+// typically come from abusive clients rather than people making mistakes.
+var defaultFailureClassifications = []string{
+	RequestTooLargeError,
+	LimitExceededError,
+	DeserializationError,
+	SpamError,
+	CsrfError,
+	ReplayError,
+}
+
+// done finishes a binding: it completes the checks of the body, records
+// the binding as the source of the errors without one when the Provenance
+// option is set, and reports the failure.
+func (b *Binder) done(dst interface{}, req *http.Request, name string, err error) error {
+	err = finishBodyChecks(req, err)
+	if errors, ok := err.(Errors); ok && b.Provenance {
+		for i := range errors {
+			if errors[i].Source == "" {
+				errors[i].Source = name
+			}
+		}
+	}
+	b.reportFailure(dst, req, err)
+	return err
+}
+
+// reportFailure calls the OnFailure callback when err has one of the failure
+// classifications of the binder.
+func (b *Binder) reportFailure(dst interface{}, req *http.Request, err error) {
+	if b.OnFailure == nil || err == nil || b.dryRun {
+		return
+	}
+
+	classifications := b.FailureClassifications
+	if classifications == nil {
+		classifications = defaultFailureClassifications
+	}
+
+	errors := toErrors(err)
+	for _, classification := range classifications {
+		if errors.Has(classification) {
+			clientIP, _, splitErr := net.SplitHostPort(req.RemoteAddr)
+			if splitErr != nil {
+				clientIP = req.RemoteAddr
+			}
+			b.OnFailure(Failure{
+				Classification: classification,
+				ClientIP:       clientIP,
+				Model:          reflect.TypeOf(dst),
+				Err:            err,
+			})
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package binding
+
+import (
+	"reflect"
+
+	. "gopkg.in/check.v1"
+)
+
+type failureSuite struct{}
+
+var _ = Suite(&failureSuite{})
+
+func (s *failureSuite) Test_OnFailureMalformed(c *C) {
+	failures := []Failure{}
+	binder := &Binder{OnFailure: func(failure Failure) { failures = append(failures, failure) }}
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title":`, jsonContentType)
+	req.RemoteAddr = "192.0.2.1:1234"
+	err := binder.Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+	c.Assert(failures, DeepEquals, []Failure{{
+		Classification: DeserializationError,
+		ClientIP:       "192.0.2.1",
+		Model:          reflect.TypeOf(&post),
+		Err:            ErrorDeserialization,
+	}})
+}
+
+func (s *failureSuite) Test_OnFailureIgnoresValidationErrors(c *C) {
+	failures := []Failure{}
+	binder := &Binder{OnFailure: func(failure Failure) { failures = append(failures, failure) }}
+	person := RequiredName{}
+	err := binder.Form().Bind(&person, newRequest(`POST`, ``, ``, formContentType))
+
+	c.Assert(err, NotNil)
+	c.Assert(failures, HasLen, 0)
+}
+
+func (s *failureSuite) Test_OnFailureClassifications(c *C) {
+	failures := []Failure{}
+	binder := &Binder{
+		OnFailure:              func(failure Failure) { failures = append(failures, failure) },
+		FailureClassifications: []string{RequiredError},
+	}
+	person := RequiredName{}
+	err := binder.Form().Bind(&person, newRequest(`POST`, ``, ``, formContentType))
+
+	c.Assert(failures, HasLen, 1)
+	c.Assert(failures[0].Classification, Equals, RequiredError)
+	c.Assert(failures[0].Err, DeepEquals, err)
+}
+
+func (s *failureSuite) Test_OnFailureSpam(c *C) {
+	failures := []Failure{}
+	binder := &Binder{OnFailure: func(failure Failure) { failures = append(failures, failure) }}
+	form := HoneypotForm{}
+	err := binder.Form().Bind(&form, newRequest(`POST`, ``, `website=http://spam.example`, formContentType))
+
+	c.Assert(err, NotNil)
+	c.Assert(failures, HasLen, 1)
+	c.Assert(failures[0].Classification, Equals, SpamError)
+}
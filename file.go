@@ -0,0 +1,137 @@
+package binding
+
+import (
+	"bufio"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strings"
+)
+
+// File is an uploaded file with its metadata. Fields of type *File or []*File
+// are bound like *multipart.FileHeader fields, without tying the model to the
+// mime/multipart package. The size rules (MinSize, MaxSize and Length) applied
+// to a File field check its size in bytes.
+type File struct {
+	// Filename is the name of the file as sent by the client
+	Filename string
+
+	// Size is the size of the file in bytes
+	Size int64
+
+	// ContentType is sniffed from the content of the file
+	ContentType string
+
+	header *multipart.FileHeader
+	open   func() (multipart.File, error)
+	remove func() error
+}
+
+var (
+	fileType      = reflect.TypeOf((*File)(nil))
+	fileSliceType = reflect.TypeOf([]*File(nil))
+)
+
+// isFileField reports whether a field of type typ receives uploaded files.
+func isFileField(typ reflect.Type) bool {
+	return typ == fhType || typ == fhSliceType || typ == fileType || typ == fileSliceType
+}
+
+// formFieldName returns the form key of a field, as used by the form mapper.
+func formFieldName(field reflect.StructField) string {
+	name, _ := parseTag(field.Tag.Get("form"))
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return normalizeFormKey(name)
+}
+
+// newFile returns the File for an uploaded file header.
+func newFile(fh *multipart.FileHeader) *File {
+	return &File{
+		Filename:    fh.Filename,
+		Size:        fh.Size,
+		ContentType: sniffContentType(fh),
+		header:      fh,
+	}
+}
+
+// Open opens the uploaded file for reading.
+func (f *File) Open() (multipart.File, error) {
+	if f.open != nil {
+		return f.open()
+	}
+	return f.header.Open()
+}
+
+// UploadStore receives the file parts of a multipart form that is bound in
+// streaming mode, see Binder.StreamMultipart.
+type UploadStore interface {
+	// Store consumes the content of a file part posted under the form field
+	// name and returns a function that opens the stored file again.
+	Store(name, filename string, header textproto.MIMEHeader, content io.Reader) (open func() (multipart.File, error), err error)
+}
+
+// RemovableUploadStore is an UploadStore that can remove the files it stored.
+// When a binding in streaming mode fails, after the parts were stored, on a
+// later part, a limit, CSRF, an unknown part or validation, the stored files
+// of the request are removed so a rejected request leaves no uploads behind.
+type RemovableUploadStore interface {
+	UploadStore
+
+	// StoreRemovable stores a file part like Store and also returns a function
+	// that removes the stored file again.
+	StoreRemovable(name, filename string, header textproto.MIMEHeader, content io.Reader) (open func() (multipart.File, error), remove func() error, err error)
+}
+
+// storeFile hands a file part to the store and returns the File describing it,
+// with the size counted and the content type sniffed while it streams by.
+func storeFile(store UploadStore, name string, part *multipart.Part) (*File, error) {
+	content := bufio.NewReaderSize(part, 512)
+	head, err := content.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, readError(err)
+	}
+
+	file := &File{Filename: part.FileName(), ContentType: http.DetectContentType(head)}
+	counted := &countingReader{ReadCloser: io.NopCloser(content), count: &file.Size}
+	if removable, ok := store.(RemovableUploadStore); ok {
+		file.open, file.remove, err = removable.StoreRemovable(name, part.FileName(), part.Header, counted)
+	} else {
+		file.open, err = store.Store(name, part.FileName(), part.Header, counted)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// removeStoredFiles removes the files stored by a RemovableUploadStore.
+func removeStoredFiles(files map[string][]*File) {
+	for _, stored := range files {
+		for _, file := range stored {
+			if file.remove != nil {
+				file.remove()
+			}
+		}
+	}
+}
+
+// sniffContentType detects the content type from the first 512 bytes of
+// the file, falling back to the content type sent by the client.
+func sniffContentType(fh *multipart.FileHeader) string {
+	file, err := fh.Open()
+	if err != nil {
+		return fh.Header.Get("Content-Type")
+	}
+	defer file.Close()
+
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fh.Header.Get("Content-Type")
+	}
+	return http.DetectContentType(buffer[:n])
+}
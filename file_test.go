@@ -2,6 +2,7 @@ package binding
 
 import (
 	"bytes"
+	"io"
 	"mime/multipart"
 	"net/http"
 
@@ -16,6 +17,28 @@ type fileInfo struct {
 	data      string
 }
 
+type (
+	Profile struct {
+		Avatar *multipart.FileHeader `form:"avatar"`
+	}
+
+	Account struct {
+		Profile *Profile
+	}
+
+	Document struct {
+		Title string  `form:"title"`
+		Cover *File   `form:"cover" binding:"MaxSize(16)"`
+		Pages []*File `form:"page"`
+	}
+
+	Attachments struct {
+		HeaderImage *multipart.FileHeader `form:"headerImage"`
+		Files       map[string][]*multipart.FileHeader
+		FirstFiles  map[string]*multipart.FileHeader
+	}
+)
+
 var _ = Suite(&fileSuite{})
 
 func (s *fileSuite) Test_SingleFile(c *C) {
@@ -94,6 +117,131 @@ func (s *fileSuite) Test_SingleFileAndMultipleFiles(c *C) {
 	c.Assert(unpackFileData(blogPost.Pictures[1]), Equals, "This tool translates JSON into Go structs: http://mholt.github.io/json-to-go/")
 }
 
+func (s *fileSuite) Test_FileInNestedStructPointer(c *C) {
+	account := Account{}
+	req := buildRequestWithFile([]fileInfo{
+		fileInfo{
+			fieldName: "profile.avatar",
+			fileName:  "gopher.txt",
+			data:      "Gopher",
+		},
+	})
+	err := MultipartForm.Bind(&account, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(account.Profile, NotNil)
+	c.Assert(account.Profile.Avatar.Filename, Equals, "gopher.txt")
+	c.Assert(unpackFileData(account.Profile.Avatar), Equals, "Gopher")
+}
+
+func (s *fileSuite) Test_UnclaimedFilesIntoMap(c *C) {
+	attachments := Attachments{}
+	req := buildRequestWithFile([]fileInfo{
+		fileInfo{
+			fieldName: "headerImage",
+			fileName:  "header.txt",
+			data:      "Header",
+		},
+		fileInfo{
+			fieldName: "invoice",
+			fileName:  "invoice.txt",
+			data:      "Invoice",
+		},
+		fileInfo{
+			fieldName: "receipt",
+			fileName:  "receipt-1.txt",
+			data:      "Receipt 1",
+		},
+		fileInfo{
+			fieldName: "receipt",
+			fileName:  "receipt-2.txt",
+			data:      "Receipt 2",
+		},
+	})
+	binder := &Binder{UnknownParts: RejectUnknownParts}
+	err := binder.MultipartForm().Bind(&attachments, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(attachments.HeaderImage.Filename, Equals, "header.txt")
+
+	c.Assert(attachments.Files, HasLen, 2)
+	c.Assert(attachments.Files["invoice"], HasLen, 1)
+	c.Assert(unpackFileData(attachments.Files["invoice"][0]), Equals, "Invoice")
+	c.Assert(attachments.Files["receipt"], HasLen, 2)
+	c.Assert(attachments.Files["receipt"][1].Filename, Equals, "receipt-2.txt")
+
+	c.Assert(attachments.FirstFiles, HasLen, 2)
+	c.Assert(attachments.FirstFiles["receipt"].Filename, Equals, "receipt-1.txt")
+}
+
+func (s *fileSuite) Test_NoUnclaimedFiles(c *C) {
+	attachments := Attachments{}
+	req := buildRequestWithFile([]fileInfo{
+		fileInfo{
+			fieldName: "headerImage",
+			fileName:  "header.txt",
+			data:      "Header",
+		},
+	})
+	err := MultipartForm.Bind(&attachments, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(attachments.Files, IsNil)
+	c.Assert(attachments.FirstFiles, IsNil)
+}
+
+func (s *fileSuite) Test_FileMetadata(c *C) {
+	document := Document{}
+	req := buildRequestWithFile([]fileInfo{
+		fileInfo{
+			fieldName: "cover",
+			fileName:  "cover.html",
+			data:      "<html></html>",
+		},
+		fileInfo{
+			fieldName: "page",
+			fileName:  "page-1.txt",
+			data:      "Page 1",
+		},
+		fileInfo{
+			fieldName: "page",
+			fileName:  "page-2.txt",
+			data:      "Page 2",
+		},
+	})
+	err := MultipartForm.Bind(&document, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(document.Cover.Filename, Equals, "cover.html")
+	c.Assert(document.Cover.Size, Equals, int64(13))
+	c.Assert(document.Cover.ContentType, Equals, "text/html; charset=utf-8")
+
+	c.Assert(document.Pages, HasLen, 2)
+	c.Assert(document.Pages[1].Filename, Equals, "page-2.txt")
+	c.Assert(document.Pages[1].ContentType, Equals, "text/plain; charset=utf-8")
+
+	f, err := document.Pages[0].Open()
+	c.Assert(err, IsNil)
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "Page 1")
+}
+
+func (s *fileSuite) Test_FileMetadataSizeRule(c *C) {
+	document := Document{}
+	req := buildRequestWithFile([]fileInfo{
+		fileInfo{
+			fieldName: "cover",
+			fileName:  "cover.txt",
+			data:      "This cover is way too large",
+		},
+	})
+	err := MultipartForm.Bind(&document, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Cover"}, Classification: MaxSizeError, Message: "MaxSize"}})
+}
+
 func buildRequestWithFile(files []fileInfo) *http.Request {
 	b := &bytes.Buffer{}
 	w := multipart.NewWriter(b)
@@ -1,11 +1,17 @@
 package binding
 
 import (
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strings"
 )
 
-type formBinding struct{}
+type formBinding struct {
+	binder *Binder
+}
 
 func (_ formBinding) Name() string {
 	return "form"
@@ -20,16 +26,36 @@ func (_ formBinding) Name() string {
 // keys, for example: key=val1&key=val2&key=val3
 // An interface pointer can be added as a second argument in order
 // to map the struct to a specific interface.
-func (_ formBinding) Bind(dst interface{}, req *http.Request) error {
+func (f formBinding) Bind(dst interface{}, req *http.Request) (err error) {
 
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr {
 		return ErrorInputNotByReference
 	}
 
+	binder := binderOrDefault(f.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	var cache *bindCache
+	cached := false
+	defer func() {
+		if cached {
+			//the failure was reported by the binding that was cached
+			return
+		}
+		err = binder.done(dst, req, f.Name(), err)
+		if cache != nil {
+			cache.store(binder, v, err)
+		}
+	}()
+
 	//reset element to zero variant
 	v = v.Elem()
 	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		if binder.NoPointerAllocation {
+			return ErrorInputIsNilPointer
+		}
 		v.Set(reflect.New(v.Type().Elem()))
 	}
 
@@ -38,13 +64,127 @@ func (_ formBinding) Bind(dst interface{}, req *http.Request) error {
 		return ErrorInputIsNotStructure
 	}
 
+	if cache = requestCache(req); cache != nil {
+		if entry, ok := cache.load(binder, v); ok {
+			cached = true
+			return entry.err
+		}
+	}
+
 	// Format validation of the request body or the URL would add considerable overhead,
 	// and ParseForm does not complain when URL encoding is off.
 	// Because an empty request body or url can also mean absence of all needed values,
 	// it is not in all cases a bad request, so let's return 422.
-	parseErr := req.ParseForm()
-	if parseErr != nil {
-		return ErrorDeserialization
+	if err := binder.checkHeaders(req); err != nil {
+		return err
+	}
+	//a request without a body binds from its query string, like one with an empty body
+	if req.Body == nil {
+		req.Body = http.NoBody
+	}
+	stats := requestStats(req)
+	countBody(req, stats)
+	if err := binder.decodeBody(req); err != nil {
+		return err
+	}
+	var decodeErrors Errors
+	if binder.Plus != PlusAsSpace || binder.KeyDecodeErrors {
+		var err error
+		if decodeErrors, err = parseFormValues(binder, req); err != nil {
+			return err
+		}
+		if !binder.KeyDecodeErrors && !binder.PartialForm && decodeErrors.Len() > 0 {
+			return ErrorDeserialization
+		}
+	} else if parseErr := req.ParseForm(); parseErr != nil {
+		if !binder.PartialForm {
+			return readError(parseErr)
+		}
+		decodeErrors = Errors{{Classification: DeserializationError, Message: parseErr.Error()}}
+	}
+
+	if err := binder.Limits.checkForm(req.Form, nil); err != nil {
+		return err
+	}
+
+	mapper := newFormMapper(binder, req.Form, nil).withSources(req, req.PostForm)
+	if err := mapper.mapStruct(v); err != nil {
+		return err
+	}
+	mapper.collectStats(stats)
+	binder.warn(req, mapper.warnings)
+
+	if err := binder.verifyCsrf(req, mapper); err != nil {
+		return err
+	}
+
+	if decodeErrors.Len() > 0 {
+		if errors, ok := binder.validate(dst, req).(Errors); ok {
+			decodeErrors = append(decodeErrors, errors...)
+		}
+		return decodeErrors
+	}
+	return binder.validate(dst, req)
+}
+
+// maxFormSize is the largest url encoded body parsed, like http.Request.ParseForm.
+const maxFormSize = 10 << 20
+
+// parseFormValues fills the Form and PostForm of req like ParseForm does, but
+// decodes every pair on its own, honouring the plus policy of the binder. Pairs
+// that fail to decode are left out and returned as errors on their key.
+func parseFormValues(binder *Binder, req *http.Request) (Errors, error) {
+	var errors Errors
+
+	if req.PostForm == nil {
+		req.PostForm = url.Values{}
+		if req.Body != nil && (req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH") {
+			contentType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if contentType == MIMEPOSTForm {
+				body, err := io.ReadAll(io.LimitReader(req.Body, maxFormSize+1))
+				if err != nil || len(body) > maxFormSize {
+					if !binder.PartialForm {
+						return nil, readError(err)
+					}
+					errors = append(errors, Error{Classification: DeserializationError, Message: ErrorDeserialization.Error()})
+				} else {
+					errors = decodeFormPairs(req.PostForm, string(body), binder.Plus == LiteralPlus, errors)
+				}
+			}
+		}
+	}
+
+	if req.Form == nil {
+		req.Form = url.Values{}
+		for key, values := range req.PostForm {
+			req.Form[key] = append(req.Form[key], values...)
+		}
+		literalPlus := binder.Plus == LiteralPlus || binder.Plus == LiteralPlusInQuery
+		errors = decodeFormPairs(req.Form, req.URL.RawQuery, literalPlus, errors)
+	}
+	return errors, nil
+}
+
+// decodeFormPairs adds the pairs of the url encoded query to values and appends
+// an error for every pair that fails to decode.
+func decodeFormPairs(values url.Values, query string, literalPlus bool, errors Errors) Errors {
+	unescape := url.QueryUnescape
+	if literalPlus {
+		unescape = url.PathUnescape
+	}
+
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, keyErr := unescape(rawKey)
+		value, valueErr := unescape(rawValue)
+		if keyErr != nil || valueErr != nil || strings.Contains(pair, ";") {
+			errors = append(errors, Error{FieldNames: []string{rawKey}, Classification: DeserializationError, Message: "Malformed value"})
+			continue
+		}
+		values[key] = append(values[key], value)
 	}
-	return mapForm("", v, req.Form, nil)
+	return errors
 }
@@ -1,6 +1,14 @@
 package binding
 
-import . "gopkg.in/check.v1"
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
 
 type formSuite struct{}
 
@@ -51,6 +59,16 @@ func (s *formSuite) Test_HappyPathWithNullPointer(c *C) {
 	c.Assert(post, DeepEquals, &Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
 }
 
+func (s *formSuite) Test_NoPointerAllocation(c *C) {
+	post := (*Post)(nil)
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&content=Lorem+ipsum+dolor+sit+amet`, formContentType)
+	binder := &Binder{NoPointerAllocation: true}
+	err := binder.Form().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorInputIsNilPointer)
+	c.Assert(post, IsNil)
+}
+
 func (s *formSuite) Test_EmptyBody(c *C) {
 	post := Post{}
 	req := newRequest(`POST`, ``, ``, formContentType)
@@ -106,6 +124,15 @@ func (s *formSuite) Test_RequiredNestedStructFieldNotSpecified(c *C) {
 	c.Assert(blogPost, DeepEquals, BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1})
 }
 
+func (s *formSuite) Test_NestedStructPointerAllocatedOnDemand(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&id=1&coauthor.name=Matt+Holt`, formContentType)
+	err := Form.Bind(&blogPost, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost, DeepEquals, BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Coauthor: &Person{Name: "Matt Holt"}})
+}
+
 func (s *formSuite) Test_MultipleValuesIntoSlice(c *C) {
 	blogPost := BlogPost{}
 	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&id=1&author.name=Matt+Holt&rating=4&rating=3&rating=5`, formContentType)
@@ -159,3 +186,606 @@ func (s *formSuite) Test_EmbedStructPointerPtr(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(embedPerson, DeepEquals, &EmbedPerson{&Person{Name: "Glorious Post Title", Email: "Lorem ipsum dolor sit amet"}})
 }
+
+func (s *formSuite) Test_EmbedStructPointerCollapsesZeroValue(c *C) {
+	embedPerson := EmbedPerson{}
+	req := newRequest(`GET`, `?name=&email=`, ``, formContentType)
+	err := Form.Bind(&embedPerson, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(embedPerson, DeepEquals, EmbedPerson{})
+}
+
+func (s *formSuite) Test_EmbedStructPointerKeepZeroValue(c *C) {
+	embedPerson := EmbedPerson{}
+	req := newRequest(`GET`, `?name=&email=`, ``, formContentType)
+	binder := &Binder{KeepZeroEmbeds: true}
+	err := binder.Form().Bind(&embedPerson, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(embedPerson, DeepEquals, EmbedPerson{&Person{}})
+}
+
+func (s *formSuite) Test_EmbedStructPointerKeepZeroValueNotSubmitted(c *C) {
+	embedPerson := EmbedPerson{}
+	req := newRequest(`GET`, `?other=value`, ``, formContentType)
+	binder := &Binder{KeepZeroEmbeds: true}
+	err := binder.Form().Bind(&embedPerson, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(embedPerson, DeepEquals, EmbedPerson{})
+}
+
+func (s *formSuite) Test_EmbedStructPointerKeepZeroTag(c *C) {
+	embedPerson := KeepZeroEmbedPerson{}
+	req := newRequest(`GET`, `?name=&email=`, ``, formContentType)
+	err := Form.Bind(&embedPerson, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(embedPerson, DeepEquals, KeepZeroEmbedPerson{&Person{}})
+}
+
+type SourcedForm struct {
+	Page  int    `form:"page,query"`
+	Token string `form:"token,body"`
+	Name  string `form:"name"`
+}
+
+func (s *formSuite) Test_FieldSources(c *C) {
+	sourced := SourcedForm{}
+	req := newRequest(`POST`, `?page=2&token=fromquery&name=fromquery`, `page=3&name=frombody`, formContentType)
+	err := Form.Bind(&sourced, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(sourced, DeepEquals, SourcedForm{Page: 2, Name: "frombody"})
+}
+
+func (s *formSuite) Test_MergedSourcesFallBackToQuery(c *C) {
+	sourced := SourcedForm{}
+	req := newRequest(`POST`, `?name=fromquery`, `token=secret`, formContentType)
+	err := Form.Bind(&sourced, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(sourced, DeepEquals, SourcedForm{Token: "secret", Name: "fromquery"})
+}
+
+func (s *formSuite) Test_SeparateFormSources(c *C) {
+	sourced := SourcedForm{}
+	req := newRequest(`POST`, `?name=fromquery`, `token=secret`, formContentType)
+	binder := &Binder{SeparateFormSources: true}
+	err := binder.Form().Bind(&sourced, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(sourced, DeepEquals, SourcedForm{Token: "secret"})
+}
+
+func (s *formSuite) Test_SeparateFormSourcesGET(c *C) {
+	sourced := SourcedForm{}
+	req := newRequest(`GET`, `?name=fromquery&page=1`, ``, formContentType)
+	binder := &Binder{SeparateFormSources: true}
+	err := binder.Form().Bind(&sourced, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(sourced, DeepEquals, SourcedForm{Page: 1, Name: "fromquery"})
+}
+
+func (s *formSuite) Test_MultipleValuesUseFirst(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `?title=Polluted`, `title=Glorious+Post+Title`, formContentType)
+	err := Form.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+}
+
+func (s *formSuite) Test_MultipleValuesRejected(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `?title=Polluted`, `title=Glorious+Post+Title`, formContentType)
+	binder := &Binder{MultipleValues: RejectMultipleValues}
+	err := binder.Form().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"title"}, Classification: MultipleValuesError, Message: "Multiple values"}})
+}
+
+func (s *formSuite) Test_MultipleValuesRejectedForTypedFields(c *C) {
+	binder := &Binder{MultipleValues: RejectMultipleValues}
+	polluted := []struct {
+		model interface{}
+		key   string
+		body  string
+	}{
+		{&SignedForm{}, "signature", `signature=deadbeef&signature=cafebabe`},
+		{&LogFilter{}, "level", `level=info&level=error`},
+		{&Download{}, "checksum", `checksum=3q2%2B7w%3D%3D&checksum=3q2%2B7w%3D%3D`},
+		{&UserPreferences{}, "accent", `accent=%23F80&accent=%23000`},
+		{&UserPreferences{}, "zone", `zone=Europe/Amsterdam&zone=Asia/Tokyo`},
+		{&FirewallRule{}, "source", `source=1.1.1.1&source=2.2.2.2`},
+		{&Appointment{}, "start", `start=2020-01-01T00:00:00Z&start=2021-01-01T00:00:00Z`},
+		{&Store{}, "location", `location=52.37,4.89&location=52.1,5.1`},
+	}
+	for _, p := range polluted {
+		err := binder.Form().Bind(p.model, newRequest(`POST`, ``, p.body, formContentType))
+
+		c.Assert(err, DeepEquals, Errors{{FieldNames: []string{p.key}, Classification: MultipleValuesError, Message: "Multiple values"}}, Commentf("%s", p.body))
+	}
+}
+
+func (s *formSuite) Test_MultipleValuesRejectedAllowsSlices(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `rating=4&rating=3`, formContentType)
+	binder := &Binder{MultipleValues: RejectMultipleValues}
+	err := binder.Form().Bind(&blogPost, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost.Ratings, DeepEquals, []int{4, 3})
+}
+
+type RenamedForm struct {
+	Headline string `form:"headline" deprecated:"title,subject"`
+}
+
+func (s *formSuite) Test_DeprecatedName(c *C) {
+	renamed := RenamedForm{}
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, `subject=Glorious+Post+Title`, formContentType)
+	err := Form.Bind(&renamed, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(renamed, DeepEquals, RenamedForm{Headline: "Glorious Post Title"})
+	c.Assert(stats.Warnings, DeepEquals, Errors{{
+		FieldNames:     []string{"subject"},
+		Classification: DeprecatedError,
+		Message:        "Deprecated, use headline",
+		Severity:       SeverityWarning,
+	}})
+	c.Assert(stats.Aliases, DeepEquals, map[string]string{"headline": "subject"})
+	c.Assert(stats.UnknownKeys, Equals, 0)
+}
+
+func (s *formSuite) Test_DeprecatedNameWithoutStats(c *C) {
+	var warnings Errors
+	binder := &Binder{OnWarnings: func(req *http.Request, w Errors) { warnings = append(warnings, w...) }}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title`, formContentType)
+	err := binder.Form().Bind(&RenamedForm{}, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(warnings, DeepEquals, Errors{{
+		FieldNames:     []string{"title"},
+		Classification: DeprecatedError,
+		Message:        "Deprecated, use headline",
+		Severity:       SeverityWarning,
+	}})
+}
+
+func (s *formSuite) Test_DeprecatedNamePrefersNewName(c *C) {
+	renamed := RenamedForm{}
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, `title=Old&headline=Glorious+Post+Title`, formContentType)
+	err := Form.Bind(&renamed, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(renamed, DeepEquals, RenamedForm{Headline: "Glorious Post Title"})
+	c.Assert(stats.Warnings, HasLen, 0)
+	c.Assert(stats.Aliases, IsNil)
+}
+
+type RenamedBlogPost struct {
+	Author RenamedAuthor `form:"author"`
+}
+
+type RenamedAuthor struct {
+	Name string `form:"name" deprecated:"fullname"`
+}
+
+func (s *formSuite) Test_DeprecatedNameNested(c *C) {
+	renamed := RenamedBlogPost{}
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, `author.fullname=Matt+Holt`, formContentType)
+	err := Form.Bind(&renamed, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(renamed, DeepEquals, RenamedBlogPost{Author: RenamedAuthor{Name: "Matt Holt"}})
+	c.Assert(stats.Aliases, DeepEquals, map[string]string{"author.name": "author.fullname"})
+}
+
+func (s *formSuite) Test_KeyDecodeErrors(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `?content=100%`, `title=Glorious+Post+Title&bad%zz=1`, formContentType)
+	binder := &Binder{KeyDecodeErrors: true}
+	err := binder.Form().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"bad%zz"}, Classification: DeserializationError, Message: "Malformed value"},
+		{FieldNames: []string{"content"}, Classification: DeserializationError, Message: "Malformed value"},
+	})
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+	c.Assert(StatusCode(err), Equals, http.StatusBadRequest)
+}
+
+func (s *formSuite) Test_LiteralPlusInQuery(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `?content=1+1`, `title=Glorious+Post+Title`, formContentType)
+	binder := &Binder{Plus: LiteralPlusInQuery}
+	err := binder.Form().Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "1+1"})
+}
+
+func (s *formSuite) Test_LiteralPlus(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `?content=1+1`, `title=C++`, formContentType)
+	binder := &Binder{Plus: LiteralPlus}
+	err := binder.Form().Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "C++", Content: "1+1"})
+}
+
+func (s *formSuite) Test_LiteralPlusMalformedBody(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `title=%2`, formContentType)
+	binder := &Binder{Plus: LiteralPlus}
+	err := binder.Form().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *formSuite) Test_PartialForm(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, `?content=Lorem+ipsum`, `title=Glorious+Post+Title&other=%2`, formContentType)
+	binder := &Binder{PartialForm: true}
+	err := binder.Form().Bind(&post, req)
+
+	c.Assert(err, NotNil)
+	c.Assert(IsMalformed(err), Equals, true)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum"})
+}
+
+type RequiredName struct {
+	Name string `form:"name" binding:"Required"`
+}
+
+func (s *formSuite) Test_PartialFormReportsValidationErrors(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `name=%2`, formContentType)
+	binder := &Binder{PartialForm: true}
+	err := binder.Form().Bind(&person, req)
+
+	errs, ok := err.(Errors)
+	c.Assert(ok, Equals, true)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Classification, Equals, DeserializationError)
+	c.Assert(errs[1], DeepEquals, Error{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"})
+}
+
+type HoneypotForm struct {
+	Title   string `form:"title"`
+	Website string `form:"website" honeypot:"true"`
+}
+
+func (s *formSuite) Test_HoneypotEmpty(c *C) {
+	form := HoneypotForm{}
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&website=`, formContentType)
+	err := Form.Bind(&form, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(form, DeepEquals, HoneypotForm{Title: "Glorious Post Title"})
+	c.Assert(stats.UnknownKeys, Equals, 0)
+}
+
+func (s *formSuite) Test_HoneypotFilled(c *C) {
+	form := HoneypotForm{}
+	req := newRequest(`POST`, ``, `title=Cheap+pills&website=http://spam.example`, formContentType)
+	err := Form.Bind(&form, req)
+
+	c.Assert(err, DeepEquals, Errors{{Classification: SpamError, Message: "Spam"}})
+}
+
+func (s *formSuite) Test_HoneypotFlagged(c *C) {
+	form := HoneypotForm{}
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, `title=Cheap+pills&website=http://spam.example`, formContentType)
+	binder := &Binder{FlagSpam: true}
+	err := binder.Form().Bind(&form, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(form, DeepEquals, HoneypotForm{Title: "Cheap pills"})
+	c.Assert(stats.Warnings, DeepEquals, Errors{{Classification: SpamError, Message: "Spam", Severity: SeverityWarning}})
+}
+
+func (s *formSuite) Test_Provenance(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `bad%zz=1`, formContentType)
+	binder := &Binder{KeyDecodeErrors: true, Provenance: true}
+	err := binder.Form().Bind(&person, req)
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"bad%zz"}, Classification: DeserializationError, Message: "Malformed value", Source: "form"},
+		{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required", Source: "rule:Required binding.RequiredName.Name"},
+	})
+}
+
+func (s *formSuite) Test_BracketNotation(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, `?coauthor[name]=Ann`, `title=Glorious&author[name]=Matt&author[email]=matt@example.com&readers[1][name]=Bob&rating[]=4&rating[]=5`, formContentType)
+	err := Form.Bind(&blogPost, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost.Author, DeepEquals, Person{Name: "Matt", Email: "matt@example.com"})
+	c.Assert(blogPost.Coauthor, DeepEquals, &Person{Name: "Ann"})
+	c.Assert(blogPost.Readers, DeepEquals, []Person{{}, {Name: "Bob"}})
+	c.Assert(blogPost.Ratings, DeepEquals, []int{4, 5})
+}
+
+func (s *formSuite) Test_NormalizeFormKey(c *C) {
+	for key, expected := range map[string]string{
+		"author":                "author",
+		"author[name]":          "author.name",
+		"author[address][city]": "author.address.city",
+		"items[0].sku":          "items.0.sku",
+		"tags[]":                "tags",
+		"tags[][name]":          "tags[][name]",
+		"author[name":           "author[name",
+		"author]name[":          "author]name[",
+	} {
+		c.Assert(normalizeFormKey(key), Equals, expected, Commentf("%q", key))
+	}
+}
+
+type NamedEmbeds struct {
+	Post         `form:"post"`
+	Person       `form:"owner"`
+	*EmbedPerson `form:"reviewer"`
+}
+
+func (s *formSuite) Test_DotNotationNamedEmbeds(c *C) {
+	embeds := NamedEmbeds{}
+	req := newRequest(`POST`, ``, `post.title=Glorious&owner.name=Matt&reviewer.name=Ann&name=Ignored`, formContentType)
+	err := Form.Bind(&embeds, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(embeds.Post, DeepEquals, Post{Title: "Glorious"})
+	c.Assert(embeds.Person, DeepEquals, Person{Name: "Matt"})
+	c.Assert(embeds.EmbedPerson, DeepEquals, &EmbedPerson{Person: &Person{Name: "Ann"}})
+}
+
+type Order struct {
+	Items []OrderLine `form:"items"`
+}
+
+type OrderLine struct {
+	Sku string `form:"sku" binding:"Required"`
+	Qty int    `form:"qty"`
+}
+
+func (s *formSuite) Test_IndexedKeysForSlicesOfStructs(c *C) {
+	order := Order{}
+	req := newRequest(`POST`, ``, `items[0].sku=A&items[0].qty=2&items[1].sku=B&items[2][qty]=1`, formContentType)
+	err := Form.Bind(&order, req)
+
+	c.Assert(order.Items, DeepEquals, []OrderLine{{Sku: "A", Qty: 2}, {Sku: "B"}, {Qty: 1}})
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Items.2.Sku"}, Classification: RequiredError, Message: "Required"}})
+}
+
+type Checkout struct {
+	Cart Order `form:"cart"`
+}
+
+func (s *formSuite) Test_IndexedKeysForNestedSlicesOfStructs(c *C) {
+	checkout := Checkout{}
+	req := newRequest(`POST`, ``, `cart[items][0][sku]=A&cart.items.1.sku=B&cart.items.1.qty=3`, formContentType)
+	err := Form.Bind(&checkout, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(checkout.Cart.Items, DeepEquals, []OrderLine{{Sku: "A"}, {Sku: "B", Qty: 3}})
+}
+
+type GuardedPost struct {
+	sync.Mutex
+	Title    string        `form:"title" binding:"Required"`
+	Updates  chan string   `form:"updates"`
+	OnChange func()        `form:"onchange"`
+	Guard    *sync.RWMutex `binding:"Required"`
+}
+
+func (s *formSuite) Test_UnbindableFields(c *C) {
+	stats := &Stats{}
+	post := &GuardedPost{}
+	req := WithStats(newRequest(`POST`, ``, `title=Glorious&updates=x&onchange=y`, formContentType), stats)
+	binder := &Binder{WarnUnbindable: true}
+	post.Lock()
+	err := binder.Form().Bind(post, req)
+	post.Unlock()
+
+	c.Assert(err, IsNil)
+	c.Assert(post.Title, Equals, "Glorious")
+	c.Assert(stats.Warnings, DeepEquals, Errors{
+		{FieldNames: []string{"mutex"}, Classification: UnbindableError, Message: "Unbindable sync.Mutex", Severity: SeverityWarning},
+		{FieldNames: []string{"updates"}, Classification: UnbindableError, Message: "Unbindable chan string", Severity: SeverityWarning},
+		{FieldNames: []string{"onchange"}, Classification: UnbindableError, Message: "Unbindable func()", Severity: SeverityWarning},
+		{FieldNames: []string{"guard"}, Classification: UnbindableError, Message: "Unbindable *sync.RWMutex", Severity: SeverityWarning},
+	})
+}
+
+type ContentItem struct {
+	Title  string `form:"title" binding:"Required"`
+	Fields map[string]string
+}
+
+func (i *ContentItem) SetField(name string, values []string) (bool, error) {
+	if !strings.HasPrefix(name, "field_") {
+		return false, nil
+	}
+	if len(values[0]) > 10 {
+		return false, errors.New("Too long")
+	}
+	if i.Fields == nil {
+		i.Fields = map[string]string{}
+	}
+	i.Fields[strings.TrimPrefix(name, "field_")] = values[0]
+	return true, nil
+}
+
+func (s *formSuite) Test_FieldSetter(c *C) {
+	item := ContentItem{}
+	req := newRequest(`POST`, ``, `title=Glorious&field_color=red&field_size=xl&other=1`, formContentType)
+	err := Form.Bind(&item, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(item, DeepEquals, ContentItem{Title: "Glorious", Fields: map[string]string{"color": "red", "size": "xl"}})
+
+	err = Form.Bind(&ContentItem{}, newRequest(`POST`, ``, `title=Glorious&field_color=transparent`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"field_color"}, Classification: DeserializationError, Message: "Too long"}})
+}
+
+type StockForm struct {
+	Quantity int   `form:"quantity" float:"round"`
+	Sizes    []int `form:"size" float:"truncate"`
+	Pallets  int   `form:"pallets" float:"error"`
+}
+
+func (s *formSuite) Test_FloatPolicy(c *C) {
+	stock := StockForm{}
+	err := Form.Bind(&stock, newRequest(`POST`, ``, `quantity=3.7&size=1.9&size=-2.5&pallets=2`, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(stock, DeepEquals, StockForm{Quantity: 4, Sizes: []int{1, -2}, Pallets: 2})
+
+	err = Form.Bind(&StockForm{}, newRequest(`POST`, ``, `pallets=2.5`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"pallets"}, Classification: IntegerTypeError, Message: "Not an integer"}})
+}
+
+type Measurements struct {
+	Population int64     `form:"population"`
+	Budget     uint      `form:"budget"`
+	Ratio      float64   `form:"ratio"`
+	Samples    []int     `form:"sample"`
+	Weights    []float32 `form:"weight"`
+}
+
+func (s *formSuite) Test_RelaxedNumbers(c *C) {
+	measurements := Measurements{}
+	body := `population=1_000_000&budget=2.5e3&ratio=1_000.5&sample=1e2&sample=1_0&sample=1__0&weight=1_5e-1&weight=_1`
+	binder := &Binder{RelaxedNumbers: true}
+	err := binder.Form().Bind(&measurements, newRequest(`POST`, ``, body, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(measurements, DeepEquals, Measurements{Population: 1000000, Budget: 2500, Ratio: 1000.5, Samples: []int{100, 10, 0}, Weights: []float32{1.5, 0}})
+
+	measurements = Measurements{}
+	err = Form.Bind(&measurements, newRequest(`POST`, ``, body, formContentType))
+	c.Assert(err, IsNil)
+	c.Assert(measurements, DeepEquals, Measurements{Ratio: 1000.5, Samples: []int{0, 0, 0}, Weights: []float32{1.5, 0}})
+}
+
+type CacheSettings struct {
+	HitRatio float64         `form:"hit_ratio" unit:"percent"`
+	MaxSize  int64           `form:"max_size" unit:"bytes"`
+	Chunks   []uint          `form:"chunk" unit:"bytes"`
+	TTL      time.Duration   `form:"ttl" unit:"duration"`
+	Backoff  []time.Duration `form:"backoff" unit:"duration"`
+}
+
+func (s *formSuite) Test_Units(c *C) {
+	settings := CacheSettings{}
+	body := `hit_ratio=75%25&max_size=10MB&chunk=1KiB&chunk=512&chunk=1.5k&ttl=2h&backoff=100ms&backoff=1s`
+	err := Form.Bind(&settings, newRequest(`POST`, ``, body, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(settings, DeepEquals, CacheSettings{
+		HitRatio: 0.75,
+		MaxSize:  10000000,
+		Chunks:   []uint{1024, 512, 1536},
+		TTL:      2 * time.Hour,
+		Backoff:  []time.Duration{100 * time.Millisecond, time.Second},
+	})
+
+	settings = CacheSettings{}
+	err = Form.Bind(&settings, newRequest(`POST`, ``, `hit_ratio=0.5&ttl=1000`, formContentType))
+	c.Assert(err, IsNil)
+	c.Assert(settings, DeepEquals, CacheSettings{HitRatio: 0.5, TTL: 1000})
+
+	err = Form.Bind(&CacheSettings{}, newRequest(`POST`, ``, `max_size=10 parsecs`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"max_size"}, Classification: UnitError, Message: "Invalid unit"}})
+}
+
+type JobSettings struct {
+	Timeout time.Duration   `form:"timeout" query:"timeout" binding:"MaxDuration(1h)"`
+	Retries []time.Duration `form:"retry"`
+}
+
+func (s *formSuite) Test_Duration(c *C) {
+	settings := JobSettings{}
+	err := Form.Bind(&settings, newRequest(`POST`, ``, `timeout=30s&retry=5m&retry=1h30m`, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(settings, DeepEquals, JobSettings{Timeout: 30 * time.Second, Retries: []time.Duration{5 * time.Minute, 90 * time.Minute}})
+
+	settings = JobSettings{}
+	err = Query(&settings, newRequest(`GET`, `/jobs?timeout=2h`, ``, ``))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Timeout"}, Classification: MaxDurationError, Message: "MaxDuration"}})
+	c.Assert(settings.Timeout, Equals, 2*time.Hour)
+
+	err = Form.Bind(&JobSettings{}, newRequest(`POST`, ``, `retry=5m&retry=soon`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"retry"}, Classification: DurationError, Message: "Invalid duration"}})
+}
+
+type PatchProfile struct {
+	Nickname *string  `form:"nickname"`
+	Age      *int     `form:"age"`
+	Public   *bool    `form:"public"`
+	Score    *float64 `form:"score"`
+	Lucky    []*int   `form:"lucky"`
+	Bio      *string  `form:"bio"`
+}
+
+func (s *formSuite) Test_PointerScalars(c *C) {
+	profile := PatchProfile{}
+	err := Form.Bind(&profile, newRequest(`PATCH`, ``, `nickname=&age=0&public=false&lucky=7&lucky=13`, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(*profile.Nickname, Equals, "")
+	c.Assert(*profile.Age, Equals, 0)
+	c.Assert(*profile.Public, Equals, false)
+	c.Assert(profile.Score, IsNil)
+	c.Assert(profile.Bio, IsNil)
+	c.Assert(profile.Lucky, HasLen, 2)
+	c.Assert(*profile.Lucky[1], Equals, 13)
+}
+
+func (s *formSuite) Test_PointerScalarsInvalid(c *C) {
+	for body, expected := range map[string]Errors{
+		`age=abc`:     {{FieldNames: []string{"age"}, Classification: IntegerTypeError, Message: "Not an integer"}},
+		`public=mayb`: {{FieldNames: []string{"public"}, Classification: BooleanTypeError, Message: "Not a boolean"}},
+		`score=high`:  {{FieldNames: []string{"score"}, Classification: FloatTypeError, Message: "Not a number"}},
+	} {
+		profile := PatchProfile{}
+		err := Form.Bind(&profile, newRequest(`PATCH`, ``, body, formContentType))
+
+		c.Assert(err, DeepEquals, expected, Commentf("%s", body))
+		c.Assert(profile, DeepEquals, PatchProfile{}, Commentf("%s", body))
+	}
+}
+
+type PublishSchedule struct {
+	PublishAt *time.Time `form:"publish_at"`
+	Archive   *time.Time `form:"archive"`
+}
+
+func (s *formSuite) Test_PointerTime(c *C) {
+	schedule := PublishSchedule{}
+	err := Form.Bind(&schedule, newRequest(`POST`, ``, `publish_at=2020-01-01T00:00:00Z`, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(schedule.PublishAt, NotNil)
+	c.Assert(schedule.PublishAt.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), Equals, true)
+	c.Assert(schedule.Archive, IsNil)
+
+	schedule = PublishSchedule{}
+	err = Form.Bind(&schedule, newRequest(`POST`, ``, `archive=someday`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"archive"}, Classification: TimeTypeError, Message: "Invalid time"}})
+	c.Assert(schedule.Archive, IsNil)
+}
@@ -0,0 +1,89 @@
+package binding
+
+import (
+	"mime/multipart"
+	"strings"
+)
+
+// normalizeFormKey rewrites the bracket notation of PHP and Rails style form
+// keys into the dot notation the form mapper uses: author[name] becomes
+// author.name, items[0][sku] becomes items.0.sku and tags[] becomes tags.
+// Keys with unbalanced brackets are returned as is.
+func normalizeFormKey(key string) string {
+	if !strings.Contains(key, "[") {
+		return key
+	}
+
+	normalized := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '[':
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				return key
+			}
+			segment := key[i+1 : i+end]
+			if strings.ContainsAny(segment, "[.") {
+				return key
+			}
+			if segment != "" {
+				normalized = append(normalized, '.')
+				normalized = append(normalized, segment...)
+			} else if i+end+1 != len(key) {
+				return key
+			}
+			i += end
+		case ']':
+			return key
+		default:
+			normalized = append(normalized, key[i])
+		}
+	}
+	return string(normalized)
+}
+
+// normalizeFormValues returns the values with their keys in dot notation,
+// merging the values of keys that normalize to the same key.
+func normalizeFormValues(values map[string][]string) map[string][]string {
+	normalized := make(map[string][]string, len(values))
+	changed := false
+	for key, value := range values {
+		normalizedKey := normalizeFormKey(key)
+		changed = changed || normalizedKey != key
+		normalized[normalizedKey] = append(normalized[normalizedKey], value...)
+	}
+	if !changed {
+		return values
+	}
+	return normalized
+}
+
+// normalizeFormFiles returns the file headers with their keys in dot notation.
+func normalizeFormFiles(values map[string][]*multipart.FileHeader) map[string][]*multipart.FileHeader {
+	normalized := make(map[string][]*multipart.FileHeader, len(values))
+	changed := false
+	for key, value := range values {
+		normalizedKey := normalizeFormKey(key)
+		changed = changed || normalizedKey != key
+		normalized[normalizedKey] = append(normalized[normalizedKey], value...)
+	}
+	if !changed {
+		return values
+	}
+	return normalized
+}
+
+// normalizeStoredFiles returns the stored files with their keys in dot notation.
+func normalizeStoredFiles(values map[string][]*File) map[string][]*File {
+	normalized := make(map[string][]*File, len(values))
+	changed := false
+	for key, value := range values {
+		normalizedKey := normalizeFormKey(key)
+		changed = changed || normalizedKey != key
+		normalized[normalizedKey] = append(normalized[normalizedKey], value...)
+	}
+	if !changed {
+		return values
+	}
+	return normalized
+}
@@ -0,0 +1,86 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Point is a geographic coordinate pair. A Point field binds from a single
+// "lat,lng" value, like 52.37,4.89, or from separate lat and lng keys, and
+// decodes from JSON as such a string, as a {"lat": 52.37, "lng": 4.89} object
+// or as a GeoJSON Point. Its coordinates are validated with the Latitude and
+// Longitude rules.
+type Point struct {
+	Latitude  float64 `json:"lat" form:"lat" binding:"Latitude"`
+	Longitude float64 `json:"lng" form:"lng" binding:"Longitude"`
+}
+
+var pointType = reflect.TypeOf(Point{})
+
+// ParsePoint parses a "lat,lng" coordinate pair.
+func ParsePoint(value string) (Point, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return Point{}, errors.New("point is not a lat,lng pair")
+	}
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{Latitude: latitude, Longitude: longitude}, nil
+}
+
+// UnmarshalJSON decodes a "lat,lng" string, a lat and lng object or a GeoJSON
+// Point, whose coordinates are ordered longitude first.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var pair string
+	if err := json.Unmarshal(data, &pair); err == nil {
+		point, err := ParsePoint(pair)
+		if err != nil {
+			return err
+		}
+		*p = point
+		return nil
+	}
+
+	var object struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+		Latitude    float64   `json:"lat"`
+		Longitude   float64   `json:"lng"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+
+	if object.Type == "" {
+		*p = Point{Latitude: object.Latitude, Longitude: object.Longitude}
+		return nil
+	}
+	if object.Type != "Point" || len(object.Coordinates) < 2 {
+		return errors.New("GeoJSON geometry is not a Point")
+	}
+	*p = Point{Latitude: object.Coordinates[1], Longitude: object.Coordinates[0]}
+	return nil
+}
+
+// isCoordinate reports whether the value of a Latitude or Longitude rule lies
+// within -limit and limit.
+func isCoordinate(v reflect.Value, limit float64) bool {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return !math.IsNaN(v.Float()) && math.Abs(v.Float()) <= limit
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return math.Abs(float64(v.Int())) <= limit
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package binding
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type geoSuite struct{}
+
+var _ = Suite(&geoSuite{})
+
+type Store struct {
+	Name     string `json:"name" form:"name"`
+	Location Point  `json:"location" form:"location"`
+	Near     *Point `json:"near" query:"near"`
+}
+
+func (s *geoSuite) Test_FormPointPair(c *C) {
+	store := Store{}
+	err := Bind(&store, newRequest(`POST`, `/stores?near=52.1,5.1`, `name=Centraal&location=52.37,4.89`, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(store, DeepEquals, Store{Name: "Centraal", Location: Point{Latitude: 52.37, Longitude: 4.89}, Near: &Point{Latitude: 52.1, Longitude: 5.1}})
+}
+
+func (s *geoSuite) Test_FormPointKeys(c *C) {
+	store := Store{}
+	err := Form.Bind(&store, newRequest(`POST`, ``, `location.lat=52.37&location[lng]=4.89`, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(store.Location, Equals, Point{Latitude: 52.37, Longitude: 4.89})
+}
+
+func (s *geoSuite) Test_FormPointMalformed(c *C) {
+	err := Form.Bind(&Store{}, newRequest(`POST`, ``, `location=52.37`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"location"}, Classification: DeserializationError, Message: "Malformed point"}})
+}
+
+func (s *geoSuite) Test_JSONPoint(c *C) {
+	for _, body := range []string{
+		`{"location": "52.37,4.89"}`,
+		`{"location": {"lat": 52.37, "lng": 4.89}}`,
+		`{"location": {"type": "Point", "coordinates": [4.89, 52.37]}}`,
+	} {
+		store := Store{}
+		err := JSON.Bind(&store, newRequest(`POST`, ``, body, jsonContentType))
+
+		c.Assert(err, IsNil, Commentf("%s", body))
+		c.Assert(store.Location, Equals, Point{Latitude: 52.37, Longitude: 4.89}, Commentf("%s", body))
+	}
+
+	err := JSON.Bind(&Store{}, newRequest(`POST`, ``, `{"location": {"type": "LineString", "coordinates": [[4.89, 52.37]]}}`, jsonContentType))
+	c.Assert(err, NotNil)
+}
+
+func (s *geoSuite) Test_PointIsValidated(c *C) {
+	err := JSON.Bind(&Store{}, newRequest(`POST`, ``, `{"location": "91,4.89", "near": {"lat": 52.37, "lng": -181}}`, jsonContentType))
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Location.Latitude"}, Classification: LatitudeError, Message: "Latitude"},
+		{FieldNames: []string{"Near.Longitude"}, Classification: LongitudeError, Message: "Longitude"},
+	})
+}
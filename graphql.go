@@ -0,0 +1,102 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GraphQLRequest is the envelope of a GraphQL over HTTP request.
+type GraphQLRequest struct {
+	Query         string          `json:"query"`
+	OperationName string          `json:"operationName,omitempty"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	Extensions    json.RawMessage `json:"extensions,omitempty"`
+}
+
+// BindGraphQL reads the GraphQL request envelope using the default binder,
+// see Binder.BindGraphQL.
+func BindGraphQL(req *http.Request, variables interface{}) (*GraphQLRequest, error) {
+	return defaultBinder.BindGraphQL(req, variables)
+}
+
+// BindGraphQL reads the GraphQL request envelope from the query string of a
+// GET request, from an application/graphql body holding the query, or from a
+// JSON body with the query, operationName and variables members. The variables
+// are decoded into variables, a pointer to a struct or nil to skip them, which
+// is then validated. A request without a query fails with a RequiredError on
+// "query", variables that do not decode with a DeserializationError on
+// "variables".
+func (b *Binder) BindGraphQL(req *http.Request, variables interface{}) (envelope *GraphQLRequest, err error) {
+	if req == nil {
+		return nil, ErrorNilRequest
+	}
+	defer func() { err = b.done(variables, req, "graphql", err) }()
+
+	envelope = &GraphQLRequest{}
+	if req.Method == "GET" {
+		query := req.URL.Query()
+		envelope.Query = query.Get("query")
+		envelope.OperationName = query.Get("operationName")
+		envelope.Variables = rawParameter(query.Get("variables"))
+		envelope.Extensions = rawParameter(query.Get("extensions"))
+	} else {
+		if err := b.checkHeaders(req); err != nil {
+			return nil, err
+		}
+		if req.Body == nil {
+			return nil, ErrorDeserialization
+		}
+		countBody(req, requestStats(req))
+		if err := b.decodeBody(req); err != nil {
+			return nil, err
+		}
+		defer req.Body.Close()
+
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, readError(err)
+		}
+
+		_, mediaType, _ := normalizeMediaType(req.Header.Get("Content-Type"))
+		switch {
+		case mediaType == MIMEGraphQL:
+			envelope.Query = string(data)
+			envelope.OperationName = req.URL.Query().Get("operationName")
+			envelope.Variables = rawParameter(req.URL.Query().Get("variables"))
+		case mediaType == MIMEJSON || strings.HasSuffix(mediaType, "+json"):
+			if err := b.Limits.checkJSON(data); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(data, envelope); err != nil {
+				return nil, ErrorDeserialization
+			}
+		default:
+			return nil, ErrorUnsupportedContentType
+		}
+	}
+
+	if strings.TrimSpace(envelope.Query) == "" {
+		return envelope, Errors{NewFieldError("query", RequiredError, "Required")}
+	}
+	if variables == nil {
+		return envelope, nil
+	}
+
+	if raw := bytes.TrimSpace(envelope.Variables); len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, variables); err != nil {
+			return envelope, Errors{NewFieldError("variables", DeserializationError, "Malformed variables")}
+		}
+	}
+	return envelope, b.validate(variables, req)
+}
+
+// rawParameter returns the json document passed in a query string parameter.
+func rawParameter(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
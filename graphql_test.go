@@ -0,0 +1,59 @@
+package binding
+
+import (
+	"net/url"
+
+	. "gopkg.in/check.v1"
+)
+
+type graphqlSuite struct{}
+
+var _ = Suite(&graphqlSuite{})
+
+type CreatePostVariables struct {
+	Title   string `json:"title" binding:"Required"`
+	Content string `json:"content"`
+}
+
+func (s *graphqlSuite) Test_JSONEnvelope(c *C) {
+	variables := CreatePostVariables{}
+	req := newRequest(`POST`, ``, `{"query": "mutation CreatePost($title: String!) { createPost(title: $title) { id } }", "operationName": "CreatePost", "variables": {"title": "Glorious Post Title"}}`, jsonContentType)
+	envelope, err := BindGraphQL(req, &variables)
+
+	c.Assert(err, IsNil)
+	c.Assert(envelope.OperationName, Equals, "CreatePost")
+	c.Assert(envelope.Query, Equals, "mutation CreatePost($title: String!) { createPost(title: $title) { id } }")
+	c.Assert(variables, DeepEquals, CreatePostVariables{Title: "Glorious Post Title"})
+}
+
+func (s *graphqlSuite) Test_GraphQLBody(c *C) {
+	req := newRequest(`POST`, `/graphql?variables=`+url.QueryEscape(`{"title": "Glorious Post Title"}`), `{ posts { id } }`, "application/graphql")
+	variables := CreatePostVariables{}
+	envelope, err := BindGraphQL(req, &variables)
+
+	c.Assert(err, IsNil)
+	c.Assert(envelope.Query, Equals, `{ posts { id } }`)
+	c.Assert(variables.Title, Equals, "Glorious Post Title")
+}
+
+func (s *graphqlSuite) Test_GET(c *C) {
+	req := newRequest(`GET`, `/graphql?query=`+url.QueryEscape(`{ posts { id } }`)+`&operationName=Posts`, ``, ``)
+	envelope, err := BindGraphQL(req, nil)
+
+	c.Assert(err, IsNil)
+	c.Assert(envelope, DeepEquals, &GraphQLRequest{Query: `{ posts { id } }`, OperationName: "Posts"})
+}
+
+func (s *graphqlSuite) Test_Errors(c *C) {
+	_, err := BindGraphQL(newRequest(`POST`, ``, `{"variables": {}}`, jsonContentType), nil)
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"query"}, Classification: RequiredError, Message: "Required"}})
+
+	_, err = BindGraphQL(newRequest(`POST`, ``, `{"query": "{ posts }", "variables": {"title": 1}}`, jsonContentType), &CreatePostVariables{})
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"variables"}, Classification: DeserializationError, Message: "Malformed variables"}})
+
+	_, err = BindGraphQL(newRequest(`POST`, ``, `{"query": "{ posts }"}`, jsonContentType), &CreatePostVariables{})
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Title"}, Classification: RequiredError, Message: "Required"}})
+
+	_, err = BindGraphQL(newRequest(`POST`, ``, `query`, "text/plain"), nil)
+	c.Assert(err, Equals, ErrorUnsupportedContentType)
+}
@@ -0,0 +1,64 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// ImportRecords streams the CSV or NDJSON records of the request into fn
+// using the default binder, see Binder.ImportRecords.
+func ImportRecords(req *http.Request, newRecord func() interface{}, fn func(index int, record interface{}) error) (*BatchResult, error) {
+	return defaultBinder.ImportRecords(req, newRecord, fn)
+}
+
+// ImportRecords reads the records of a CSV or NDJSON request one at a time,
+// without buffering the whole body. Each record is bound into a new value
+// returned by newRecord, a pointer to a struct, and validated; fn is called
+// with the records that pass, and the errors of the others are collected into
+// the returned BatchResult. Once MaxRecordErrors records failed the import stops
+// with ErrorTooManyInvalidRecords, next to the report of the records read so
+// far. An error returned by fn stops the import and is returned as is.
+func (b *Binder) ImportRecords(req *http.Request, newRecord func() interface{}, fn func(index int, record interface{}) error) (result *BatchResult, err error) {
+	if req == nil {
+		return nil, ErrorNilRequest
+	}
+	defer func() { err = finishBodyChecks(req, err) }()
+
+	total, invalid := 0, 0
+	var errors Errors
+	each := func(index int, record interface{}, recordErrors Errors) error {
+		total++
+		if recordErrors.Len() == 0 {
+			return fn(index, record)
+		}
+
+		errors, _ = appendRecordErrors(errors, index, recordErrors)
+		if invalid++; b.MaxRecordErrors > 0 && invalid >= b.MaxRecordErrors {
+			return ErrorTooManyInvalidRecords
+		}
+		return nil
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "ndjson"):
+		err = b.readNDJSON(req, newRecord, each)
+	case strings.Contains(contentType, "csv"):
+		structType := reflect.TypeOf(newRecord()).Elem()
+		err = b.readCSV(req, structType, func(index int, record reflect.Value, recordErrors Errors) error {
+			if !record.IsValid() {
+				return each(index, nil, recordErrors)
+			}
+			return each(index, record.Interface(), recordErrors)
+		})
+	default:
+		return nil, ErrorUnsupportedContentType
+	}
+	if err != nil && err != ErrorTooManyInvalidRecords {
+		return nil, err
+	}
+
+	result, _ = newBatchResult(total, errors)
+	return result, err
+}
@@ -0,0 +1,55 @@
+package binding
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type importSuite struct{}
+
+var _ = Suite(&importSuite{})
+
+func (s *importSuite) Test_ImportCSV(c *C) {
+	imported := []string{}
+	req := newRequest(`POST`, ``, "full name,email\nMatt Holt,matt@example.com\n,invalid\nMichael Boke,michael@example.com\n", csvContentType)
+	result, err := ImportRecords(req, func() interface{} { return &Contact{} }, func(index int, record interface{}) error {
+		imported = append(imported, record.(*Contact).Name)
+		return nil
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(imported, DeepEquals, []string{"Matt Holt", "Michael Boke"})
+	c.Assert(result.Total, Equals, 3)
+	c.Assert(result.Valid, Equals, 2)
+	c.Assert(result.Records, HasLen, 1)
+	c.Assert(result.Records[0].Index, Equals, 1)
+}
+
+func (s *importSuite) Test_ImportNDJSONWithErrorCap(c *C) {
+	imported := 0
+	binder := &Binder{MaxRecordErrors: 2}
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n{}\n{\"Name\":\n{\"Name\": \"Michael Boke\"}\n", ndjsonContentType)
+	result, err := binder.ImportRecords(req, func() interface{} { return &RequiredName{} }, func(index int, record interface{}) error {
+		imported++
+		return nil
+	})
+
+	c.Assert(err, Equals, ErrorTooManyInvalidRecords)
+	c.Assert(imported, Equals, 1)
+	c.Assert(result, DeepEquals, &BatchResult{Total: 3, Valid: 1, Invalid: 2, Records: []RecordErrors{
+		{Index: 1, Errors: Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}}},
+		{Index: 2, Errors: Errors{{FieldNames: []string{}, Classification: DeserializationError, Message: ErrorDeserialization.Error()}}},
+	}})
+}
+
+func (s *importSuite) Test_ImportCallbackError(c *C) {
+	stored := errors.New("store failed")
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n", ndjsonContentType)
+	result, err := ImportRecords(req, func() interface{} { return &RequiredName{} }, func(index int, record interface{}) error {
+		return stored
+	})
+
+	c.Assert(err, Equals, stored)
+	c.Assert(result, IsNil)
+}
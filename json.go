@@ -1,13 +1,16 @@
 package binding
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"net/http"
 	"reflect"
 )
 
-type jsonBinding struct{}
+type jsonBinding struct {
+	binder *Binder
+}
 
 func (_ jsonBinding) Name() string {
 	return "json"
@@ -18,18 +21,99 @@ func (_ jsonBinding) Name() string {
 // validated, but no error handling is actually performed here.
 // An interface pointer can be added as a second argument in order
 // to map the struct to a specific interface.
-func (_ jsonBinding) Bind(dst interface{}, req *http.Request) error {
+func (j jsonBinding) Bind(dst interface{}, req *http.Request) (err error) {
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr {
 		return ErrorInputNotByReference
 	}
 
+	binder := binderOrDefault(j.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = binder.done(dst, req, j.Name(), err) }()
+	if binder.NoPointerAllocation && isNilPointerReference(v) {
+		return ErrorInputIsNilPointer
+	}
+
+	if err := binder.checkHeaders(req); err != nil {
+		return err
+	}
 	if req.Body != nil {
+		countBody(req, requestStats(req))
+		if err := binder.decodeBody(req); err != nil {
+			return err
+		}
 		defer req.Body.Close()
-		err := json.NewDecoder(req.Body).Decode(dst)
+
+		body := io.Reader(req.Body)
+		unixTimes := hasUnixTimeFields(v.Type(), map[reflect.Type]bool{})
+		if binder.Limits.enabled() || unixTimes {
+			data, err := io.ReadAll(req.Body)
+			if err != nil {
+				return readError(err)
+			}
+			if err := binder.Limits.checkJSON(data); err != nil {
+				return err
+			}
+			if unixTimes {
+				data = convertUnixTimes(data, v.Type())
+			}
+			body = bytes.NewReader(data)
+		}
+
+		decoder := json.NewDecoder(body)
+		if binder.DisallowUnknownFields {
+			decoder.DisallowUnknownFields()
+		}
+		err := decoder.Decode(dst)
 		if err != nil && err != io.EOF {
-			return ErrorDeserialization
+			return readError(err)
+		}
+		if err := binder.Limits.checkRaw(v); err != nil {
+			return err
 		}
 	}
+	return binder.validate(dst, req)
+}
+
+// setJSON decodes the json document posted under key into a field tagged with
+// the json option, `form:"metadata,json"`. The document is either a form value
+// or an uploaded file, so multipart requests can send it as a part of type
+// application/json next to their file parts. A document that does not decode
+// fails the binding with a DeserializationError on the key.
+func (m *formMapper) setJSON(key string, options tagOptions, structField reflect.Value) error {
+	if !structField.CanSet() {
+		m.skipped++
+		return nil
+	}
+
+	var data []byte
+	if values, exists := m.values(key, options); exists && len(values) > 0 {
+		data = []byte(values[0])
+	} else if files := m.files(key); len(files) > 0 {
+		file, err := files[0].Open()
+		if err != nil {
+			return readError(err)
+		}
+		defer file.Close()
+		if data, err = io.ReadAll(file); err != nil {
+			return readError(err)
+		}
+	} else {
+		m.skipped++
+		return nil
+	}
+
+	m.bind(key)
+	if err := m.binder.Limits.checkJSON(data); err != nil {
+		return err
+	}
+	value := reflect.New(structField.Type())
+	value.Elem().Set(structField)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return Errors{NewFieldError(key, DeserializationError, "Malformed JSON")}
+	}
+	structField.Set(value.Elem())
 	return nil
 }
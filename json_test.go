@@ -96,3 +96,31 @@ func (s *jsonSuite) Test_SliceOfPosts(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(posts, DeepEquals, []Post{Post{Title: "First Post"}, Post{Title: "Second Post"}})
 }
+
+func (s *jsonSuite) Test_HappyPathWithNullPointer(c *C) {
+	post := (*Post)(nil)
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	err := JSON.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, &Post{Title: "Glorious Post Title"})
+}
+
+func (s *jsonSuite) Test_NoPointerAllocation(c *C) {
+	post := (*Post)(nil)
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, jsonContentType)
+	binder := &Binder{NoPointerAllocation: true}
+	err := binder.JSON().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorInputIsNilPointer)
+	c.Assert(post, IsNil)
+}
+
+func (s *jsonSuite) Test_DisallowUnknownFields(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title", "unknown": true}`, jsonContentType)
+	binder := &Binder{DisallowUnknownFields: true}
+	err := binder.JSON().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
@@ -0,0 +1,474 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type jsonPatchBinding struct {
+	binder *Binder
+}
+
+func (_ jsonPatchBinding) Name() string {
+	return "json-patch"
+}
+
+// jsonPatchOperation is a single operation of a JSON Patch document.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"-"`
+
+	// hasValue tells a null value apart from a missing value
+	hasValue bool
+}
+
+// UnmarshalJSON decodes an operation and records whether it has a value; a
+// null value is a value, it clears a nullable member.
+func (o *jsonPatchOperation) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	type operation jsonPatchOperation
+	if err := json.Unmarshal(data, (*operation)(o)); err != nil {
+		return err
+	}
+	o.Value, o.hasValue = members["value"]
+	return nil
+}
+
+// JSONPatch applies a JSON Patch (RFC 6902) body, a list of add, remove,
+// replace, move, copy and test operations, onto the json representation of
+// the struct that is passed in, and validates the patched struct. Operations
+// that are malformed or cannot be applied are reported as a PatchError with
+// the index of the operation as field name; the struct is then left untouched.
+func (j jsonPatchBinding) Bind(dst interface{}, req *http.Request) (err error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	binder := binderOrDefault(j.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = binder.done(dst, req, j.Name(), err) }()
+
+	v = v.Elem()
+	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		if binder.NoPointerAllocation {
+			return ErrorInputIsNilPointer
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return ErrorInputIsNotStructure
+	}
+
+	if err := binder.checkHeaders(req); err != nil {
+		return err
+	}
+
+	if req.Body != nil {
+		countBody(req, requestStats(req))
+		if err := binder.decodeBody(req); err != nil {
+			return err
+		}
+		defer req.Body.Close()
+
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return readError(err)
+		}
+
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := binder.Limits.checkJSON(data); err != nil {
+				return err
+			}
+
+			var operations []jsonPatchOperation
+			if err := json.Unmarshal(data, &operations); err != nil {
+				return ErrorDeserialization
+			}
+			if err := binder.applyJSONPatch(v, operations); err != nil {
+				return err
+			}
+		}
+	}
+	return binder.validate(dst, req)
+}
+
+// applyJSONPatch applies the operations to the json document of the struct and
+// merges the differences back into the struct, so fields that are not part of
+// the json document keep their value.
+func (b *Binder) applyJSONPatch(v reflect.Value, operations []jsonPatchOperation) error {
+	var errors Errors
+	for i, operation := range operations {
+		if !operation.valid() {
+			errors.Add([]string{strconv.Itoa(i)}, PatchError, "Invalid operation")
+		}
+	}
+	if errors.Len() > 0 {
+		return errors
+	}
+
+	original, err := jsonDocument(v.Interface())
+	if err != nil {
+		return ErrorDeserialization
+	}
+	patched, err := jsonDocument(v.Interface())
+	if err != nil {
+		return ErrorDeserialization
+	}
+
+	for i, operation := range operations {
+		var message string
+		patched, message = operation.apply(patched)
+		if message != "" {
+			errors.Add([]string{strconv.Itoa(i)}, PatchError, message)
+			return errors
+		}
+	}
+
+	data, err := json.Marshal(patched)
+	if err != nil || json.Unmarshal(data, reflect.New(v.Type()).Interface()) != nil {
+		return ErrorDeserialization
+	}
+
+	diff, ok := jsonMergeDiff(original, patched).(map[string]interface{})
+	if !ok {
+		return ErrorDeserialization
+	}
+	data, err = json.Marshal(diff)
+	if err != nil {
+		return ErrorDeserialization
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return ErrorDeserialization
+	}
+	return b.mergePatchStruct(v, patch)
+}
+
+// valid reports whether the operation has the members its op requires.
+func (o jsonPatchOperation) valid() bool {
+	if o.Path == nil || !isJSONPointer(*o.Path) {
+		return false
+	}
+
+	switch o.Op {
+	case "add", "replace", "test":
+		return o.hasValue
+	case "remove":
+		return true
+	case "move", "copy":
+		return o.From != nil && isJSONPointer(*o.From)
+	}
+	return false
+}
+
+// apply applies the operation to the document and returns the new document,
+// or a message describing why the operation failed.
+func (o jsonPatchOperation) apply(doc interface{}) (interface{}, string) {
+	path := jsonPointerTokens(*o.Path)
+
+	switch o.Op {
+	case "add", "replace", "test":
+		value, err := jsonDocumentFrom(o.Value)
+		if err != nil {
+			return doc, "Invalid value"
+		}
+		if o.Op == "test" {
+			current, ok := jsonPointerGet(doc, path)
+			if !ok {
+				return doc, "Path not found"
+			}
+			if !jsonEqual(current, value) {
+				return doc, "Test failed"
+			}
+			return doc, ""
+		}
+		if o.Op == "replace" {
+			if _, ok := jsonPointerGet(doc, path); !ok {
+				return doc, "Path not found"
+			}
+			var ok bool
+			if doc, _, ok = jsonPointerRemove(doc, path); !ok {
+				return doc, "Path not found"
+			}
+		}
+		result, ok := jsonPointerAdd(doc, path, value)
+		if !ok {
+			return doc, "Path not found"
+		}
+		return result, ""
+	case "remove":
+		result, _, ok := jsonPointerRemove(doc, path)
+		if !ok {
+			return doc, "Path not found"
+		}
+		return result, ""
+	case "move", "copy":
+		from := jsonPointerTokens(*o.From)
+		value, ok := jsonPointerGet(doc, from)
+		if !ok {
+			return doc, "From not found"
+		}
+		if o.Op == "move" {
+			if strings.HasPrefix(*o.Path+"/", *o.From+"/") && *o.Path != *o.From {
+				return doc, "Cannot move into itself"
+			}
+			if doc, _, ok = jsonPointerRemove(doc, from); !ok {
+				return doc, "From not found"
+			}
+		} else {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return doc, "Invalid value"
+			}
+			if value, err = jsonDocumentFrom(data); err != nil {
+				return doc, "Invalid value"
+			}
+		}
+		result, ok := jsonPointerAdd(doc, path, value)
+		if !ok {
+			return doc, "Path not found"
+		}
+		return result, ""
+	}
+	return doc, "Invalid operation"
+}
+
+// jsonDocument returns the generic json document of a value, keeping numbers
+// as json.Number.
+func jsonDocument(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return jsonDocumentFrom(data)
+}
+
+func jsonDocumentFrom(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc interface{}
+	err := decoder.Decode(&doc)
+	return doc, err
+}
+
+// isJSONPointer reports whether path is a json pointer (RFC 6901).
+func isJSONPointer(path string) bool {
+	return path == "" || strings.HasPrefix(path, "/")
+}
+
+// jsonPointerTokens splits a json pointer into its unescaped reference tokens.
+func jsonPointerTokens(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens
+}
+
+// jsonArrayIndex parses an array index token, which may be at most max.
+func jsonArrayIndex(token string, max int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, false
+	}
+	return index, true
+}
+
+func jsonPointerGet(doc interface{}, tokens []string) (interface{}, bool) {
+	for _, token := range tokens {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			doc = value
+		case []interface{}:
+			index, ok := jsonArrayIndex(token, len(node)-1)
+			if !ok {
+				return nil, false
+			}
+			doc = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+func jsonPointerAdd(doc interface{}, tokens []string, value interface{}) (interface{}, bool) {
+	if len(tokens) == 0 {
+		return value, true
+	}
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		if len(tokens) == 1 {
+			node[tokens[0]] = value
+			return node, true
+		}
+		child, ok := node[tokens[0]]
+		if !ok {
+			return doc, false
+		}
+		if child, ok = jsonPointerAdd(child, tokens[1:], value); !ok {
+			return doc, false
+		}
+		node[tokens[0]] = child
+		return node, true
+	case []interface{}:
+		if len(tokens) == 1 {
+			index := len(node)
+			if tokens[0] != "-" {
+				var ok bool
+				if index, ok = jsonArrayIndex(tokens[0], len(node)); !ok {
+					return doc, false
+				}
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, true
+		}
+		index, ok := jsonArrayIndex(tokens[0], len(node)-1)
+		if !ok {
+			return doc, false
+		}
+		child, ok := jsonPointerAdd(node[index], tokens[1:], value)
+		if !ok {
+			return doc, false
+		}
+		node[index] = child
+		return node, true
+	}
+	return doc, false
+}
+
+func jsonPointerRemove(doc interface{}, tokens []string) (interface{}, interface{}, bool) {
+	if len(tokens) == 0 {
+		return doc, nil, false
+	}
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[tokens[0]]
+		if !ok {
+			return doc, nil, false
+		}
+		if len(tokens) == 1 {
+			delete(node, tokens[0])
+			return node, child, true
+		}
+		child, removed, ok := jsonPointerRemove(child, tokens[1:])
+		if !ok {
+			return doc, nil, false
+		}
+		node[tokens[0]] = child
+		return node, removed, true
+	case []interface{}:
+		index, ok := jsonArrayIndex(tokens[0], len(node)-1)
+		if !ok {
+			return doc, nil, false
+		}
+		if len(tokens) == 1 {
+			removed := node[index]
+			return append(node[:index], node[index+1:]...), removed, true
+		}
+		child, removed, ok := jsonPointerRemove(node[index], tokens[1:])
+		if !ok {
+			return doc, nil, false
+		}
+		node[index] = child
+		return node, removed, true
+	}
+	return doc, nil, false
+}
+
+// jsonEqual compares two json documents, comparing numbers by value.
+func jsonEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, errA := a.Float64()
+		y, errB := b.Float64()
+		return errA == nil && errB == nil && x == y
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// jsonMergeDiff returns the json merge patch that turns the original document
+// into the patched one.
+func jsonMergeDiff(original, patched interface{}) interface{} {
+	originalObject, ok := original.(map[string]interface{})
+	patchedObject, isObject := patched.(map[string]interface{})
+	if !ok || !isObject {
+		return patched
+	}
+
+	diff := map[string]interface{}{}
+	for key := range originalObject {
+		if _, ok := patchedObject[key]; !ok {
+			diff[key] = nil
+		}
+	}
+	for key, value := range patchedObject {
+		if previous, ok := originalObject[key]; ok && jsonEqual(previous, value) {
+			continue
+		} else if ok {
+			diff[key] = jsonMergeDiff(previous, value)
+		} else {
+			diff[key] = value
+		}
+	}
+	return diff
+}
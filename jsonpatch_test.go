@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type jsonPatchSuite struct{}
+
+var _ = Suite(&jsonPatchSuite{})
+
+const jsonPatchContentType = "application/json-patch+json"
+
+func (s *jsonPatchSuite) Test_Operations(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `[
+		{"op": "test", "path": "/title", "value": "Stored"},
+		{"op": "replace", "path": "/title", "value": "Patched"},
+		{"op": "add", "path": "/tags/1", "value": "x"},
+		{"op": "remove", "path": "/tags/0"},
+		{"op": "copy", "from": "/author", "path": "/reviewer"},
+		{"op": "move", "from": "/meta/draft", "path": "/meta/state"},
+		{"op": "remove", "path": "/summary"}
+	]`, jsonPatchContentType)
+	err := JSONPatch.Bind(&article, req)
+
+	expected := storedArticle()
+	expected.Title = "Patched"
+	expected.Tags = []string{"x", "b"}
+	expected.Reviewer = &ArticleAuthor{Name: "Matt", Email: "matt@example.com"}
+	expected.Meta = map[string]string{"lang": "en", "state": "yes"}
+	expected.Summary = nil
+	c.Assert(err, IsNil)
+	c.Assert(article, DeepEquals, expected)
+}
+
+func (s *jsonPatchSuite) Test_NullValue(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `[{"op": "replace", "path": "/summary", "value": null}, {"op": "test", "path": "/summary", "value": null}]`, jsonPatchContentType)
+	err := JSONPatch.Bind(&article, req)
+
+	expected := storedArticle()
+	expected.Summary = nil
+	c.Assert(err, IsNil)
+	c.Assert(article, DeepEquals, expected)
+}
+
+func (s *jsonPatchSuite) Test_InvalidOperations(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `[{"op": "add", "path": "/title"}, {"op": "remove", "path": "/tags/0"}, {"op": "jump", "path": "/title"}]`, jsonPatchContentType)
+	err := JSONPatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"0"}, Classification: PatchError, Message: "Invalid operation"},
+		{FieldNames: []string{"2"}, Classification: PatchError, Message: "Invalid operation"},
+	})
+	c.Assert(article, DeepEquals, storedArticle())
+}
+
+func (s *jsonPatchSuite) Test_FailedOperationLeavesStructUntouched(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `[{"op": "replace", "path": "/title", "value": "Patched"}, {"op": "test", "path": "/author/name", "value": "Ann"}]`, jsonPatchContentType)
+	err := JSONPatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"1"}, Classification: PatchError, Message: "Test failed"}})
+	c.Assert(article, DeepEquals, storedArticle())
+	c.Assert(StatusCode(err), Equals, http.StatusUnprocessableEntity)
+}
+
+func (s *jsonPatchSuite) Test_PathNotFound(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `[{"op": "remove", "path": "/tags/5"}]`, jsonPatchContentType)
+	err := JSONPatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"0"}, Classification: PatchError, Message: "Path not found"}})
+}
+
+func (s *jsonPatchSuite) Test_ValidatesPatchedResult(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `[{"op": "replace", "path": "/title", "value": ""}]`, jsonPatchContentType)
+	err := Bind(&article, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Title"}, Classification: RequiredError, Message: "Required"}})
+	c.Assert(article.ID, Equals, 7)
+}
+
+func (s *jsonPatchSuite) Test_MalformedPatch(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"op": "remove", "path": "/title"}`, jsonPatchContentType)
+	err := JSONPatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *jsonPatchSuite) Test_WrongType(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `[{"op": "replace", "path": "/tags", "value": "x"}]`, jsonPatchContentType)
+	err := JSONPatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+	c.Assert(article, DeepEquals, storedArticle())
+}
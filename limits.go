@@ -0,0 +1,258 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// Limits is a decoding budget enforced in the same way by Form, MultipartForm
+// and JSON. Exceeding any of the limits fails the binding with a single
+// LimitExceededError. A zero value disables the corresponding limit.
+type Limits struct {
+	// MaxKeys is the maximum number of form keys and file parts,
+	// or the maximum number of members of all JSON objects together.
+	MaxKeys int
+
+	// MaxValueLength is the maximum length in bytes of a single value,
+	// key or JSON string.
+	MaxValueLength int
+
+	// MaxDepth is the maximum nesting depth, the number of dot separated
+	// segments of a form key or the nesting of JSON objects and arrays.
+	MaxDepth int
+
+	// MaxSliceLength is the maximum length of a slice, the number of values
+	// posted for a form key, one more than an index in a form key like
+	// items[9].sku, or the number of elements of a JSON array.
+	MaxSliceLength int
+
+	// MaxRawSize is the maximum size in bytes of the JSON captured by a
+	// json.RawMessage or interface{} field, like a free form attributes blob.
+	// A larger value fails the binding with a LimitExceededError on the field.
+	MaxRawSize int
+
+	// MaxBytes is the total number of bytes of the request body a binding
+	// reads, once decompressed. A Content-Length over the budget fails before
+	// anything is read; a body without one fails as soon as it reads past it.
+	MaxBytes int64
+}
+
+// enabled reports whether any limit is set.
+func (l Limits) enabled() bool {
+	return l.MaxKeys > 0 || l.MaxValueLength > 0 || l.MaxDepth > 0 || l.MaxSliceLength > 0 || l.MaxRawSize > 0
+}
+
+// checkForm verifies the parsed form values and files against the limits.
+func (l Limits) checkForm(form map[string][]string, formfile map[string][]*multipart.FileHeader) error {
+	if l.MaxKeys > 0 && len(form)+len(formfile) > l.MaxKeys {
+		return limitExceeded("MaxKeys")
+	}
+
+	for key, values := range form {
+		if err := l.checkKey(key); err != nil {
+			return err
+		}
+		if l.MaxSliceLength > 0 && len(values) > l.MaxSliceLength {
+			return limitExceeded("MaxSliceLength")
+		}
+		for _, value := range values {
+			if l.MaxValueLength > 0 && len(value) > l.MaxValueLength {
+				return limitExceeded("MaxValueLength")
+			}
+		}
+	}
+
+	for key := range formfile {
+		if err := l.checkKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l Limits) checkKey(key string) error {
+	if l.MaxValueLength > 0 && len(key) > l.MaxValueLength {
+		return limitExceeded("MaxValueLength")
+	}
+	key = normalizeFormKey(key)
+	if l.MaxDepth > 0 && strings.Count(key, ".")+1 > l.MaxDepth {
+		return limitExceeded("MaxDepth")
+	}
+	if l.MaxSliceLength > 0 {
+		for _, segment := range strings.Split(key, ".") {
+			if index, err := strconv.Atoi(segment); err == nil && index >= l.MaxSliceLength {
+				return limitExceeded("MaxSliceLength")
+			}
+		}
+	}
+	return nil
+}
+
+// checkJSON walks the tokens of a JSON document and verifies them against the
+// limits. Syntax errors are left for the decoder to report.
+func (l Limits) checkJSON(data []byte) error {
+	if !l.enabled() {
+		return nil
+	}
+
+	type frame struct {
+		object    bool
+		expectKey bool
+		elements  int
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	stack := []*frame{}
+	keys := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			//end of the document, or a syntax error reported by the decoder later on
+			return nil
+		}
+
+		if token == json.Delim('}') || token == json.Delim(']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		var parent *frame
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+
+		if parent != nil && parent.object && parent.expectKey {
+			//object member name
+			parent.expectKey = false
+			keys++
+			if l.MaxKeys > 0 && keys > l.MaxKeys {
+				return limitExceeded("MaxKeys")
+			}
+			if l.MaxValueLength > 0 && len(token.(string)) > l.MaxValueLength {
+				return limitExceeded("MaxValueLength")
+			}
+			continue
+		}
+
+		switch value := token.(type) {
+		case json.Delim:
+			stack = append(stack, &frame{object: value == '{', expectKey: value == '{'})
+			if l.MaxDepth > 0 && len(stack) > l.MaxDepth {
+				return limitExceeded("MaxDepth")
+			}
+		case string:
+			if l.MaxValueLength > 0 && len(value) > l.MaxValueLength {
+				return limitExceeded("MaxValueLength")
+			}
+		}
+
+		if parent != nil && parent.object {
+			parent.expectKey = true
+		} else if parent != nil {
+			parent.elements++
+			if l.MaxSliceLength > 0 && parent.elements > l.MaxSliceLength {
+				return limitExceeded("MaxSliceLength")
+			}
+		}
+	}
+}
+
+// checkRaw verifies the size of the JSON captured by the json.RawMessage and
+// interface{} fields of the bound value.
+func (l Limits) checkRaw(v reflect.Value) error {
+	if l.MaxRawSize <= 0 {
+		return nil
+	}
+
+	var errors Errors
+	l.checkRawFields(&errors, v, "")
+	if errors.Len() > 0 {
+		return errors
+	}
+	return nil
+}
+
+func (l Limits) checkRawFields(errors *Errors, v reflect.Value, path string) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return
+		}
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			value := v.Field(i)
+			if field.Anonymous {
+				l.checkRawFields(errors, value, path)
+				continue
+			}
+
+			size := -1
+			if field.Type == rawMessageType {
+				size = value.Len()
+			} else if field.Type.Kind() == reflect.Interface && !value.IsNil() {
+				data, _ := json.Marshal(value.Interface())
+				size = len(data)
+			}
+
+			if size > l.MaxRawSize {
+				errors.Add([]string{path + field.Name}, LimitExceededError, "MaxRawSize exceeded")
+			} else if size < 0 {
+				l.checkRawFields(errors, value, path+field.Name+".")
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type() == rawMessageType {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			l.checkRawFields(errors, v.Index(i), path+strconv.Itoa(i)+".")
+		}
+	}
+}
+
+// budget caps body at MaxBytes bytes. Reading past the budget fails with a
+// LimitExceededError instead of the *http.MaxBytesError of the reader.
+func (l Limits) budget(body io.ReadCloser) io.ReadCloser {
+	if l.MaxBytes <= 0 {
+		return body
+	}
+	return budgetReader{http.MaxBytesReader(nil, body, l.MaxBytes)}
+}
+
+type budgetReader struct {
+	io.ReadCloser
+}
+
+func (r budgetReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = limitExceeded("MaxBytes")
+	}
+	return n, err
+}
+
+func limitExceeded(limit string) error {
+	return Errors{Error{Classification: LimitExceededError, Message: limit + " exceeded"}}
+}
@@ -0,0 +1,162 @@
+package binding
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type limitsSuite struct{}
+
+var _ = Suite(&limitsSuite{})
+
+func (s *limitsSuite) Test_FormWithinLimits(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title&author.name=Matt+Holt`, formContentType)
+	binder := &Binder{Limits: Limits{MaxKeys: 2, MaxValueLength: 19, MaxDepth: 2}}
+	err := binder.Form().Bind(&blogPost, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost, DeepEquals, BlogPost{Post: Post{Title: "Glorious Post Title"}, Author: Person{Name: "Matt Holt"}})
+}
+
+func (s *limitsSuite) Test_FormLimitsExceeded(c *C) {
+	body := `title=Glorious+Post+Title&author.name=Matt+Holt`
+	for _, limits := range []Limits{{MaxKeys: 1}, {MaxValueLength: 18}, {MaxDepth: 1}} {
+		blogPost := BlogPost{}
+		req := newRequest(`POST`, ``, body, formContentType)
+		binder := &Binder{Limits: limits}
+		err := binder.Form().Bind(&blogPost, req)
+
+		c.Assert(err, NotNil)
+		c.Assert(err.(Errors).Has(LimitExceededError), Equals, true)
+		c.Assert(blogPost, DeepEquals, BlogPost{})
+	}
+}
+
+func (s *limitsSuite) Test_MultipartLimitsExceeded(c *C) {
+	blogPost := BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}}
+	b, w := makeMultipartPayload(blogPost)
+	req := newMultipartRequest(b, w.FormDataContentType())
+	w.Close()
+	binder := &Binder{Limits: Limits{MaxKeys: 3}}
+	response := BlogPost{}
+	err := binder.MultipartForm().Bind(&response, req)
+
+	c.Assert(err, DeepEquals, Errors{{Classification: LimitExceededError, Message: "MaxKeys exceeded"}})
+}
+
+func (s *limitsSuite) Test_JsonWithinLimits(c *C) {
+	blogPost := BlogPost{}
+	req := newRequest(`POST`, ``, `{"title":"Glorious Post Title", "id":1, "author":{"name":"Matt Holt"}}`, jsonContentType)
+	binder := &Binder{Limits: Limits{MaxKeys: 4, MaxValueLength: 19, MaxDepth: 2}}
+	err := binder.JSON().Bind(&blogPost, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost, DeepEquals, BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}})
+}
+
+func (s *limitsSuite) Test_JsonLimitsExceeded(c *C) {
+	body := `{"title":"Glorious Post Title", "id":1, "author":{"name":"Matt Holt"}}`
+	for _, limits := range []Limits{{MaxKeys: 3}, {MaxValueLength: 18}, {MaxDepth: 1}} {
+		blogPost := BlogPost{}
+		req := newRequest(`POST`, ``, body, jsonContentType)
+		binder := &Binder{Limits: limits}
+		err := binder.JSON().Bind(&blogPost, req)
+
+		c.Assert(err, NotNil)
+		c.Assert(err.(Errors).Has(LimitExceededError), Equals, true)
+		c.Assert(blogPost, DeepEquals, BlogPost{})
+	}
+}
+
+func (s *limitsSuite) Test_JsonMalformedWithLimits(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title":"foo"`, jsonContentType)
+	binder := &Binder{Limits: Limits{MaxKeys: 10}}
+	err := binder.JSON().Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *limitsSuite) Test_MaxSliceLength(c *C) {
+	binder := &Binder{Limits: Limits{MaxSliceLength: 2}}
+	for _, body := range []string{`readers[2][name]=Bob`, `readers.5.name=Bob`, `rating=1&rating=2&rating=3`} {
+		err := binder.Form().Bind(&BlogPost{}, newRequest(`POST`, ``, body, formContentType))
+
+		c.Assert(err, DeepEquals, limitExceeded("MaxSliceLength"), Commentf("%s", body))
+	}
+
+	err := binder.JSON().Bind(&BlogPost{}, newRequest(`POST`, ``, `{"ratings": [1, 2, 3]}`, jsonContentType))
+	c.Assert(err, DeepEquals, limitExceeded("MaxSliceLength"))
+
+	err = binder.Form().Bind(&BlogPost{}, newRequest(`POST`, ``, `readers[1][name]=Bob&rating=1&rating=2`, formContentType))
+	c.Assert(err, IsNil)
+}
+
+func (s *limitsSuite) Test_MaxDepthOfBracketKeys(c *C) {
+	binder := &Binder{Limits: Limits{MaxDepth: 1}}
+	err := binder.Form().Bind(&BlogPost{}, newRequest(`POST`, ``, `author[name]=Matt`, formContentType))
+
+	c.Assert(err, DeepEquals, limitExceeded("MaxDepth"))
+}
+
+type Product struct {
+	Name       string           `json:"name"`
+	Attributes json.RawMessage  `json:"attributes"`
+	Extra      interface{}      `json:"extra"`
+	Variants   []ProductVariant `json:"variants"`
+}
+
+type ProductVariant struct {
+	Sku        string      `json:"sku"`
+	Attributes interface{} `json:"attributes"`
+}
+
+func (s *limitsSuite) Test_MaxRawSize(c *C) {
+	binder := &Binder{Limits: Limits{MaxRawSize: 16}}
+	product := Product{}
+	body := `{"name": "Chair", "attributes": {"color":"red"}, "extra": [1,2], "variants": [{"sku":"c-1","attributes":{"legs":4}}]}`
+	err := binder.JSON().Bind(&product, newRequest(`POST`, ``, body, jsonContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(string(product.Attributes), Equals, `{"color":"red"}`)
+	c.Assert(product.Extra, DeepEquals, []interface{}{float64(1), float64(2)})
+	c.Assert(product.Variants[0].Attributes, DeepEquals, map[string]interface{}{"legs": float64(4)})
+
+	body = `{"name": "Chair", "attributes": {"color": "red", "size": "xl"}, "variants": [{"sku":"c-1","attributes":{"legs":4,"wheels":5}}]}`
+	err = binder.JSON().Bind(&Product{}, newRequest(`POST`, ``, body, jsonContentType))
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Attributes"}, Classification: LimitExceededError, Message: "MaxRawSize exceeded"},
+		{FieldNames: []string{"Variants.0.Attributes"}, Classification: LimitExceededError, Message: "MaxRawSize exceeded"},
+	})
+}
+
+func (s *limitsSuite) Test_MaxBytes(c *C) {
+	exceeded := Errors{{Classification: LimitExceededError, Message: "MaxBytes exceeded"}}
+	blogPost := BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}}
+	b, w := makeMultipartPayload(blogPost)
+	w.Close()
+	bodies := map[string]string{
+		jsonContentType:         `{"title":"Glorious Post Title", "id":1, "author":{"name":"Matt Holt"}}`,
+		formContentType:         `title=Glorious+Post+Title&author.name=Matt+Holt`,
+		w.FormDataContentType(): b.String(),
+	}
+	for contentType, body := range bodies {
+		for _, contentLength := range []int64{int64(len(body)), -1} {
+			req := newRequest(`POST`, ``, body, contentType)
+			req.ContentLength = contentLength
+			binder := &Binder{Limits: Limits{MaxBytes: 32}}
+			err := binder.Bind(&BlogPost{}, req)
+
+			c.Assert(err, DeepEquals, exceeded, Commentf("%s", contentType))
+		}
+
+		req := newRequest(`POST`, ``, body, contentType)
+		binder := &Binder{Limits: Limits{MaxBytes: int64(len(body))}}
+		err := binder.Bind(&BlogPost{}, req)
+
+		c.Assert(err, IsNil, Commentf("%s", contentType))
+	}
+}
@@ -0,0 +1,153 @@
+package binding
+
+import (
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// BindingFunc adapts a function to the Binding interface, so a media type can
+// be routed to custom decoding with RegisterMediaType.
+type BindingFunc func(obj interface{}, req *http.Request) error
+
+func (_ BindingFunc) Name() string {
+	return "func"
+}
+
+func (f BindingFunc) Bind(obj interface{}, req *http.Request) error {
+	return f(obj, req)
+}
+
+// RegisterMediaType routes requests with the media type to binding. Bind
+// consults the registered media types before guessing the binding from the
+// Content-Type. A media type registered with parameters, like
+// "application/vnd.company.orders+json; version=2", only matches requests
+// with the same parameters; one registered without parameters matches any.
+// The charset parameter is ignored. It returns the binder for chaining.
+func (b *Binder) RegisterMediaType(mediaType string, binding Binding) *Binder {
+	key, _, ok := normalizeMediaType(mediaType)
+	if !ok {
+		panic("binding: invalid media type " + mediaType)
+	}
+
+	if b.mediaTypes == nil {
+		b.mediaTypes = make(map[string]Binding)
+	}
+	b.mediaTypes[key] = binding
+	return b
+}
+
+// mediaTypeBinding returns the binding registered for the content type.
+func (b *Binder) mediaTypeBinding(contentType string) (Binding, bool) {
+	if len(b.mediaTypes) == 0 {
+		return nil, false
+	}
+
+	key, bare, ok := normalizeMediaType(contentType)
+	if !ok {
+		return nil, false
+	}
+	if binding, ok := b.mediaTypes[key]; ok {
+		return binding, true
+	}
+	binding, ok := b.mediaTypes[bare]
+	return binding, ok
+}
+
+// structuredBinding selects the binding for the media type of the content
+// type by its exact name or by its structured syntax suffix (RFC 6839), so
+// application/vnd.company.orders.v2+json binds as JSON. Bind falls back to
+// guessing from the content type when it finds none.
+func (b *Binder) structuredBinding(contentType string) (Binding, bool) {
+	_, mediaType, ok := normalizeMediaType(contentType)
+	if !ok {
+		return nil, false
+	}
+
+	switch mediaType {
+	case MIMEPOSTForm:
+		return b.Form(), true
+	case MIMEMultipart:
+		return b.MultipartForm(), true
+	case MIMEMergePatch:
+		return b.JSONMergePatch(), true
+	case MIMEJSONPatch:
+		return b.JSONPatch(), true
+	case MIMENDJSON, "application/ndjson":
+		return b.NDJSON(), true
+	case MIMEJSON:
+		return b.JSON(), true
+	case MIMEXML, MIMEXML2:
+		return b.XML(), true
+	case MIMEYAML, MIMEYAML2, "application/yaml":
+		return b.YAML(), true
+	case MIMETOML:
+		return b.TOML(), true
+	case MIMEPROTOBUF, MIMEPROTOBUF2:
+		return b.Protobuf(), true
+	case MIMECBOR:
+		return b.CBOR(), true
+	case MIMECSV:
+		return b.CSV(), true
+	}
+
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		switch mediaType[i+1:] {
+		case "json":
+			return b.JSON(), true
+		case "xml":
+			return b.XML(), true
+		case "yaml":
+			return b.YAML(), true
+		case "cbor":
+			return b.CBOR(), true
+		}
+	}
+	return nil, false
+}
+
+// MediaTypeVersions selects the version registered for the exact media type
+// of the Content-Type, matched like RegisterMediaType does.
+func MediaTypeVersions(versions map[string]string) VersionSelector {
+	normalized := make(map[string]string, len(versions))
+	for mediaType, version := range versions {
+		if key, _, ok := normalizeMediaType(mediaType); ok {
+			normalized[key] = version
+		}
+	}
+
+	return func(req *http.Request) string {
+		key, bare, ok := normalizeMediaType(req.Header.Get("Content-Type"))
+		if !ok {
+			return ""
+		}
+		if version, ok := normalized[key]; ok {
+			return version
+		}
+		return normalized[bare]
+	}
+}
+
+// normalizeMediaType returns the media type with its parameters in a canonical
+// form, without the charset parameter, and the media type without parameters.
+func normalizeMediaType(value string) (string, string, bool) {
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		return "", "", false
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		if name != "charset" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	parts := []string{mediaType}
+	for _, name := range names {
+		parts = append(parts, name+"="+params[name])
+	}
+	return strings.Join(parts, "; "), mediaType, true
+}
@@ -0,0 +1,86 @@
+package binding
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type mediaTypesSuite struct{}
+
+var _ = Suite(&mediaTypesSuite{})
+
+func (s *mediaTypesSuite) Test_RegisteredMediaType(c *C) {
+	versions := []string{}
+	binder := (&Binder{}).
+		RegisterMediaType("application/vnd.company.orders+json; version=2", BindingFunc(func(obj interface{}, req *http.Request) error {
+			versions = append(versions, "2")
+			return JSON.Bind(obj, req)
+		})).
+		RegisterMediaType("application/vnd.company.orders+json", BindingFunc(func(obj interface{}, req *http.Request) error {
+			versions = append(versions, "any")
+			return JSON.Bind(obj, req)
+		}))
+
+	for _, contentType := range []string{
+		"application/vnd.company.orders+json; charset=utf-8; version=2",
+		"application/vnd.company.orders+json; version=3",
+		"Application/Vnd.Company.Orders+JSON",
+	} {
+		post := Post{}
+		req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, contentType)
+		err := binder.Bind(&post, req)
+
+		c.Assert(err, IsNil)
+		c.Assert(post.Title, Equals, "Glorious Post Title")
+	}
+	c.Assert(versions, DeepEquals, []string{"2", "any", "any"})
+}
+
+func (s *mediaTypesSuite) Test_RegisteredMediaTypeBeforeHeuristics(c *C) {
+	binder := (&Binder{}).RegisterMediaType("application/vnd.company.raw+json", Text)
+
+	webhook := RawWebhook{}
+	req := newRequest(`POST`, ``, `{"not": "decoded"}`, "application/vnd.company.raw+json")
+	err := binder.Bind(&webhook, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(webhook.Payload), Equals, `{"not": "decoded"}`)
+}
+
+func (s *mediaTypesSuite) Test_MediaTypeVersions(c *C) {
+	versions := NewVersions(MediaTypeVersions(map[string]string{
+		"application/vnd.company.orders+json":            "1",
+		"application/vnd.company.orders+json; version=2": "2",
+	})).Register("1", Post{}).Register("2", BlogPost{})
+
+	model, version, err := versions.BindVersioned(newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, "application/vnd.company.orders+json; version=2"))
+	c.Assert(err, IsNil)
+	c.Assert(version, Equals, "2")
+	c.Assert(model, FitsTypeOf, &BlogPost{})
+
+	_, version, _ = versions.BindVersioned(newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, "application/vnd.company.orders+json"))
+	c.Assert(version, Equals, "1")
+}
+
+func (s *mediaTypesSuite) Test_StructuredSyntaxSuffix(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, "application/vnd.myapi.v2+json; charset=utf-8")
+
+	c.Assert(Bind(&post, req), IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+
+	post = Post{}
+	req = newRequest(`POST`, ``, `<Post><Title>Glorious Post Title</Title></Post>`, "application/vnd.jsonapi+xml")
+
+	c.Assert(Bind(&post, req), IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
+
+func (s *mediaTypesSuite) Test_MediaTypeIsCaseInsensitive(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title"}`, "Application/JSON")
+
+	c.Assert(Bind(&post, req), IsNil)
+	c.Assert(post.Title, Equals, "Glorious Post Title")
+}
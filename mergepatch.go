@@ -0,0 +1,223 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+type jsonMergePatchBinding struct {
+	binder *Binder
+}
+
+func (_ jsonMergePatchBinding) Name() string {
+	return "json-merge-patch"
+}
+
+// JSONMergePatch applies a JSON Merge Patch (RFC 7386) body onto the struct
+// that is passed in, which usually holds the resource as loaded from storage.
+// Only the keys present in the patch are written, an explicit null resets a
+// field to its zero value (or removes a map entry), and nested objects are
+// merged into nested structs and maps. The merged struct is then validated.
+// When the patch cannot be applied the struct may be partially patched.
+func (j jsonMergePatchBinding) Bind(dst interface{}, req *http.Request) (err error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	binder := binderOrDefault(j.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = binder.done(dst, req, j.Name(), err) }()
+
+	v = v.Elem()
+	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		if binder.NoPointerAllocation {
+			return ErrorInputIsNilPointer
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return ErrorInputIsNotStructure
+	}
+
+	if err := binder.checkHeaders(req); err != nil {
+		return err
+	}
+
+	if req.Body != nil {
+		countBody(req, requestStats(req))
+		if err := binder.decodeBody(req); err != nil {
+			return err
+		}
+		defer req.Body.Close()
+
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return readError(err)
+		}
+
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := binder.Limits.checkJSON(data); err != nil {
+				return err
+			}
+			if hasUnixTimeFields(v.Type(), map[reflect.Type]bool{}) {
+				data = convertUnixTimes(data, v.Type())
+			}
+
+			var patch map[string]json.RawMessage
+			if err := json.Unmarshal(data, &patch); err != nil || patch == nil {
+				return ErrorDeserialization
+			}
+			if err := binder.mergePatchStruct(v, patch); err != nil {
+				return err
+			}
+			if err := binder.Limits.checkRaw(v); err != nil {
+				return err
+			}
+		}
+	}
+	return binder.validate(dst, req)
+}
+
+// mergePatchStruct applies the keys of the patch to the fields of the struct
+// with the matching json name, and returns ErrorDeserialization for keys
+// without a field when unknown fields are disallowed.
+func (b *Binder) mergePatchStruct(v reflect.Value, patch map[string]json.RawMessage) error {
+	for key, value := range patch {
+		field, ok := mergePatchField(v, key)
+		if !ok {
+			if b.DisallowUnknownFields {
+				return ErrorDeserialization
+			}
+			continue
+		}
+		if err := b.mergePatchValue(field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergePatchField finds the settable field of the struct the json key decodes
+// into, looking into embedded structs and allocating embedded pointers.
+func mergePatchField(v reflect.Value, key string) (reflect.Value, bool) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _ := parseTag(field.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded := v.Field(i)
+				if embedded.Kind() == reflect.Ptr {
+					if embedded.IsNil() {
+						if _, ok := mergePatchField(reflect.New(fieldType).Elem(), key); !ok || !embedded.CanSet() {
+							continue
+						}
+						embedded.Set(reflect.New(fieldType))
+					}
+					embedded = embedded.Elem()
+				}
+				if found, ok := mergePatchField(embedded, key); ok {
+					return found, true
+				}
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(key, name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// mergePatchValue merges a patch value into a field: null resets the field,
+// objects are merged into structs and maps, any other value replaces the field.
+func (b *Binder) mergePatchValue(field reflect.Value, value json.RawMessage) error {
+	value = bytes.TrimSpace(value)
+	if string(value) == "null" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	typ := field.Type()
+	if value[0] == '{' && !reflect.PtrTo(typ).Implements(jsonUnmarshalerType) {
+		switch {
+		case typ.Kind() == reflect.Struct && typ != timeType:
+			var patch map[string]json.RawMessage
+			if err := json.Unmarshal(value, &patch); err != nil {
+				return ErrorDeserialization
+			}
+			return b.mergePatchStruct(field, patch)
+		case typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct && !typ.Implements(jsonUnmarshalerType):
+			if field.IsNil() {
+				field.Set(reflect.New(typ.Elem()))
+			}
+			return b.mergePatchValue(field.Elem(), value)
+		case typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String:
+			return b.mergePatchMap(field, value)
+		}
+	}
+
+	replacement := reflect.New(typ)
+	if err := json.Unmarshal(value, replacement.Interface()); err != nil {
+		return ErrorDeserialization
+	}
+	field.Set(replacement.Elem())
+	return nil
+}
+
+// mergePatchMap merges a patch object into a map with string keys, removing
+// the entries the patch sets to null.
+func (b *Binder) mergePatchMap(field reflect.Value, value json.RawMessage) error {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(value, &patch); err != nil {
+		return ErrorDeserialization
+	}
+
+	typ := field.Type()
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(typ))
+	}
+	for key, elemValue := range patch {
+		mapKey := reflect.ValueOf(key).Convert(typ.Key())
+		if string(bytes.TrimSpace(elemValue)) == "null" {
+			field.SetMapIndex(mapKey, reflect.Value{})
+			continue
+		}
+
+		elem := reflect.New(typ.Elem()).Elem()
+		if existing := field.MapIndex(mapKey); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := b.mergePatchValue(elem, elemValue); err != nil {
+			return err
+		}
+		field.SetMapIndex(mapKey, elem)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package binding
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type mergePatchSuite struct{}
+
+var _ = Suite(&mergePatchSuite{})
+
+const mergePatchContentType = "application/merge-patch+json"
+
+type Article struct {
+	ID       int               `json:"-"`
+	Title    string            `json:"title" binding:"Required"`
+	Summary  *string           `json:"summary"`
+	Tags     []string          `json:"tags"`
+	Meta     map[string]string `json:"meta"`
+	Author   ArticleAuthor     `json:"author"`
+	Reviewer *ArticleAuthor    `json:"reviewer"`
+}
+
+type ArticleAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func storedArticle() Article {
+	summary := "Short"
+	return Article{
+		ID:      7,
+		Title:   "Stored",
+		Summary: &summary,
+		Tags:    []string{"a", "b"},
+		Meta:    map[string]string{"lang": "en", "draft": "yes"},
+		Author:  ArticleAuthor{Name: "Matt", Email: "matt@example.com"},
+	}
+}
+
+func (s *mergePatchSuite) Test_OnlySuppliedKeys(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"tags":["c"],"author":{"email":"holt@example.com"},"meta":{"draft":null,"topic":"go"}}`, mergePatchContentType)
+	err := JSONMergePatch.Bind(&article, req)
+
+	expected := storedArticle()
+	expected.Tags = []string{"c"}
+	expected.Author.Email = "holt@example.com"
+	expected.Meta = map[string]string{"lang": "en", "topic": "go"}
+	c.Assert(err, IsNil)
+	c.Assert(article, DeepEquals, expected)
+}
+
+func (s *mergePatchSuite) Test_ExplicitNull(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"summary":null,"tags":null}`, mergePatchContentType)
+	err := JSONMergePatch.Bind(&article, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(article.Summary, IsNil)
+	c.Assert(article.Tags, IsNil)
+	c.Assert(article.Title, Equals, "Stored")
+}
+
+func (s *mergePatchSuite) Test_AllocatesNestedPointer(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"reviewer":{"name":"Ann"}}`, mergePatchContentType)
+	err := JSONMergePatch.Bind(&article, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(article.Reviewer, DeepEquals, &ArticleAuthor{Name: "Ann"})
+}
+
+func (s *mergePatchSuite) Test_ValidatesMergedResult(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"title":null}`, mergePatchContentType)
+	err := JSONMergePatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Title"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *mergePatchSuite) Test_NotAnObject(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `["title"]`, mergePatchContentType)
+	err := JSONMergePatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+	c.Assert(StatusCode(err), Equals, http.StatusBadRequest)
+}
+
+func (s *mergePatchSuite) Test_WrongType(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"tags":"c"}`, mergePatchContentType)
+	err := JSONMergePatch.Bind(&article, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *mergePatchSuite) Test_DisallowUnknownFields(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"subtitle":"x"}`, mergePatchContentType)
+	binder := &Binder{DisallowUnknownFields: true}
+	err := binder.JSONMergePatch().Bind(&article, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *mergePatchSuite) Test_BindSelectsMergePatch(c *C) {
+	article := storedArticle()
+	req := newRequest(`PATCH`, ``, `{"title":"Patched"}`, mergePatchContentType)
+	err := Bind(&article, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(article.ID, Equals, 7)
+	c.Assert(article.Title, Equals, "Patched")
+	c.Assert(article.Author.Name, Equals, "Matt")
+}
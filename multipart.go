@@ -1,11 +1,15 @@
 package binding
 
 import (
+	"io"
+	"mime/multipart"
 	"net/http"
 	"reflect"
 )
 
-type multipartBinding struct{}
+type multipartBinding struct {
+	binder *Binder
+}
 
 func (_ multipartBinding) Name() string {
 	return "multipart"
@@ -15,16 +19,31 @@ func (_ multipartBinding) Name() string {
 // and handle file uploads. Like the other deserialization middleware handlers,
 // you can pass in an interface to make the interface available for injection
 // into other handlers later.
-func (_ multipartBinding) Bind(dst interface{}, req *http.Request) error {
+func (m multipartBinding) Bind(dst interface{}, req *http.Request) (err error) {
 
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr {
 		return ErrorInputNotByReference
 	}
 
+	binder := binderOrDefault(m.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	var stored map[string][]*File
+	defer func() {
+		err = binder.done(dst, req, m.Name(), err)
+		if err != nil {
+			removeStoredFiles(stored)
+		}
+	}()
+
 	//reset element to zero variant
 	v = v.Elem()
 	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		if binder.NoPointerAllocation {
+			return ErrorInputIsNilPointer
+		}
 		v.Set(reflect.New(v.Type().Elem()))
 	}
 
@@ -33,21 +52,148 @@ func (_ multipartBinding) Bind(dst interface{}, req *http.Request) error {
 		return ErrorInputIsNotStructure
 	}
 
+	stats := requestStats(req)
+
+	if binder.MaxRequestSize > 0 && req.ContentLength > binder.MaxRequestSize {
+		return ErrorRequestTooLarge
+	}
+	if err := binder.checkHeaders(req); err != nil {
+		return err
+	}
+	//a request without a body binds like an empty form
+	if req.MultipartForm == nil && (req.Body == nil || req.Body == http.NoBody) {
+		req.MultipartForm = &multipart.Form{Value: map[string][]string{}, File: map[string][]*multipart.FileHeader{}}
+	}
+	if binder.StreamMultipart && req.MultipartForm == nil {
+		countBody(req, stats)
+		if err := binder.decodeBody(req); err != nil {
+			return err
+		}
+
+		values, files, err := binder.streamMultipart(req)
+		stored = files
+		if err != nil {
+			return err
+		}
+		if err := binder.Limits.checkForm(values, nil); err != nil {
+			return err
+		}
+
+		mapper := newFormMapper(binder, values, nil).withSources(req, values)
+		mapper.storedFiles = normalizeStoredFiles(files)
+		return binder.bindMultipart(dst, v, req, mapper, stats)
+	}
+
 	// This if check is necessary due to https://github.com/martini-contrib/csrf/issues/6
 	if req.MultipartForm == nil {
+		countBody(req, stats)
+		if err := binder.decodeBody(req); err != nil {
+			return err
+		}
+
 		// Workaround for multipart forms returning nil instead of an error
 		// when content is not multipart; see https://code.google.com/p/go/issues/detail?id=6334
 		if multipartReader, err := req.MultipartReader(); err != nil {
 			// TODO: Cover this and the next error check with tests
 			return ErrorDeserialization
 		} else {
-			form, parseErr := multipartReader.ReadForm(MaxMemory)
+			form, parseErr := multipartReader.ReadForm(binder.maxMemory())
 			if parseErr != nil {
-				return ErrorDeserialization
+				return readError(parseErr)
 			}
 			req.MultipartForm = form
 		}
 	}
 
-	return mapForm("", v, req.MultipartForm.Value, req.MultipartForm.File)
+	if err := binder.Limits.checkForm(req.MultipartForm.Value, req.MultipartForm.File); err != nil {
+		return err
+	}
+
+	mapper := newFormMapper(binder, req.MultipartForm.Value, req.MultipartForm.File).withSources(req, req.MultipartForm.Value)
+	return binder.bindMultipart(dst, v, req, mapper, stats)
+}
+
+// bindMultipart maps the parts of a multipart form into v and validates dst.
+func (b *Binder) bindMultipart(dst interface{}, v reflect.Value, req *http.Request, mapper *formMapper, stats *Stats) error {
+	if err := mapper.mapStruct(v); err != nil {
+		return err
+	}
+	mapper.collectStats(stats)
+	b.warn(req, mapper.warnings)
+
+	if err := b.verifyCsrf(req, mapper); err != nil {
+		return err
+	}
+	if err := b.handleUnknownParts(mapper); err != nil {
+		return err
+	}
+	return b.validate(dst, req)
+}
+
+// streamMultipart walks the parts of a multipart body in order, without
+// ReadForm. Value parts are collected, file parts are handed to the
+// UploadStore of the binder as they arrive, or discarded without one. Like
+// ReadForm, the value parts together may use up to the MaxMemory of the
+// binder and a single value up to Limits.MaxValueLength. The files stored
+// before an error are returned with it.
+func (b *Binder) streamMultipart(req *http.Request) (map[string][]string, map[string][]*File, error) {
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return nil, nil, ErrorDeserialization
+	}
+
+	values := make(map[string][]string)
+	files := make(map[string][]*File)
+	budget := b.maxMemory()
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, files, readError(err)
+		}
+
+		name := part.FormName()
+		if name == "" {
+			part.Close()
+			continue
+		}
+
+		if part.FileName() == "" {
+			limit := budget
+			if max := int64(b.Limits.MaxValueLength); max > 0 && max < limit {
+				limit = max
+			}
+			value, err := io.ReadAll(io.LimitReader(part, limit+1))
+			part.Close()
+			if err != nil {
+				return nil, files, readError(err)
+			}
+			if int64(len(value)) > limit {
+				if b.Limits.MaxValueLength > 0 && len(value) > b.Limits.MaxValueLength {
+					return nil, files, limitExceeded("MaxValueLength")
+				}
+				return nil, files, ErrorRequestTooLarge
+			}
+			budget -= int64(len(value))
+			values[name] = append(values[name], string(value))
+			continue
+		}
+
+		if b.UploadStore == nil || b.dryRun {
+			if _, err := io.Copy(io.Discard, part); err != nil {
+				return nil, files, readError(err)
+			}
+			part.Close()
+			continue
+		}
+
+		file, err := storeFile(b.UploadStore, name, part)
+		part.Close()
+		if err != nil {
+			return nil, files, err
+		}
+		files[name] = append(files[name], file)
+	}
+	return values, files, nil
 }
@@ -2,8 +2,10 @@ package binding
 
 import (
 	"bytes"
+	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"strconv"
 
 	. "gopkg.in/check.v1"
@@ -110,6 +112,81 @@ func (s *multipartSuite) Test_BadEncoding(c *C) {
 	c.Assert(response, DeepEquals, BlogPost{})
 }
 
+func (s *multipartSuite) Test_UnknownPartsIgnored(c *C) {
+	blogPost := BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}}
+	b, w := makeMultipartPayload(blogPost)
+	w.WriteField("unknown", "value")
+	req := newMultipartRequest(b, w.FormDataContentType())
+	w.Close()
+	response := BlogPost{}
+	err := MultipartForm.Bind(&response, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(response, DeepEquals, blogPost)
+}
+
+func (s *multipartSuite) Test_UnknownPartsRejected(c *C) {
+	blogPost := BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}}
+	b, w := makeMultipartPayload(blogPost)
+	w.WriteField("unknown", "value")
+	req := newMultipartRequest(b, w.FormDataContentType())
+	w.Close()
+	binder := &Binder{UnknownParts: RejectUnknownParts}
+	response := BlogPost{}
+	err := binder.MultipartForm().Bind(&response, req)
+
+	c.Assert(err, NotNil)
+	c.Assert(err, DeepEquals, Errors{Error{FieldNames: []string{"ignored", "unknown"}, Classification: UnknownPartError, Message: "Unknown part"}})
+}
+
+func (s *multipartSuite) Test_UnknownPartsHandler(c *C) {
+	blogPost := BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}}
+	b, w := makeMultipartPayload(blogPost)
+	w.WriteField("unknown", "value")
+	req := newMultipartRequest(b, w.FormDataContentType())
+	w.Close()
+	handled := map[string][]string{}
+	binder := &Binder{
+		UnknownParts: HandleUnknownParts,
+		UnknownPartHandler: func(name string, values []string, files []*multipart.FileHeader) error {
+			handled[name] = values
+			return nil
+		},
+	}
+	response := BlogPost{}
+	err := binder.Bind(&response, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(response, DeepEquals, blogPost)
+	c.Assert(handled, DeepEquals, map[string][]string{"ignored": []string{""}, "unknown": []string{"value"}})
+}
+
+func (s *multipartSuite) Test_RequestTooLarge(c *C) {
+	blogPost := BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}}
+	b, w := makeMultipartPayload(blogPost)
+	req := newMultipartRequest(b, w.FormDataContentType())
+	w.Close()
+	binder := &Binder{MaxRequestSize: 64}
+	response := BlogPost{}
+	err := binder.MultipartForm().Bind(&response, req)
+
+	c.Assert(err, DeepEquals, ErrorRequestTooLarge)
+	c.Assert(response, DeepEquals, BlogPost{})
+}
+
+func (s *multipartSuite) Test_MaxMemorySpillsToDisk(c *C) {
+	blogPost := BlogPost{Post: Post{Title: "Glorious Post Title"}, Id: 1, Author: Person{Name: "Matt Holt"}}
+	b, w := makeMultipartPayload(blogPost)
+	req := newMultipartRequest(b, w.FormDataContentType())
+	w.Close()
+	binder := &Binder{MaxMemory: 1, MaxRequestSize: 1024 * 1024}
+	response := BlogPost{}
+	err := binder.MultipartForm().Bind(&response, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(response, DeepEquals, blogPost)
+}
+
 func makeMalformedMultipartPayload() (*bytes.Buffer, *multipart.Writer) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -153,3 +230,232 @@ func newMultipartRequest(multipart *bytes.Buffer, contentType string) *http.Requ
 	req.Header.Add("Content-Type", contentType)
 	return req
 }
+
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error {
+	return nil
+}
+
+type memoryUploadStore map[string][]byte
+
+func (s memoryUploadStore) Store(name, filename string, header textproto.MIMEHeader, content io.Reader) (func() (multipart.File, error), error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	s[filename] = data
+	return func() (multipart.File, error) {
+		return memoryFile{bytes.NewReader(data)}, nil
+	}, nil
+}
+
+func makeDocumentPayload() (*bytes.Buffer, *multipart.Writer) {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	w.WriteField("title", "Glorious Post Title")
+	cover, _ := w.CreateFormFile("cover", "cover.html")
+	cover.Write([]byte("<html></html>"))
+	page, _ := w.CreateFormFile("page", "page-1.txt")
+	page.Write([]byte("Page 1"))
+	w.Close()
+	return b, w
+}
+
+func (s *multipartSuite) Test_StreamMultipart(c *C) {
+	document := Document{}
+	store := memoryUploadStore{}
+	stats := &Stats{}
+	b, w := makeDocumentPayload()
+	req := newMultipartRequest(b, w.FormDataContentType())
+	binder := &Binder{StreamMultipart: true, UploadStore: store}
+	err := binder.MultipartForm().Bind(&document, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(req.MultipartForm, IsNil)
+	c.Assert(document.Title, Equals, "Glorious Post Title")
+	c.Assert(document.Cover.Filename, Equals, "cover.html")
+	c.Assert(document.Cover.Size, Equals, int64(13))
+	c.Assert(document.Cover.ContentType, Equals, "text/html; charset=utf-8")
+	c.Assert(document.Pages, HasLen, 1)
+	c.Assert(string(store["page-1.txt"]), Equals, "Page 1")
+	c.Assert(stats.FilesReceived, Equals, 2)
+
+	file, err := document.Pages[0].Open()
+	c.Assert(err, IsNil)
+	data, _ := io.ReadAll(file)
+	c.Assert(string(data), Equals, "Page 1")
+}
+
+func (s *multipartSuite) Test_StreamMultipartValidatesStoredFiles(c *C) {
+	b, w := makeDocumentPayload()
+	req := newMultipartRequest(b, w.FormDataContentType())
+	binder := &Binder{StreamMultipart: true, UploadStore: memoryUploadStore{}}
+	err := binder.MultipartForm().Bind(&struct {
+		Cover *File `form:"cover" binding:"MaxSize(4)"`
+	}{}, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Cover"}, Classification: MaxSizeError, Message: "MaxSize"}})
+}
+
+type removableUploadStore struct {
+	memoryUploadStore
+}
+
+func (s removableUploadStore) StoreRemovable(name, filename string, header textproto.MIMEHeader, content io.Reader) (func() (multipart.File, error), func() error, error) {
+	open, err := s.Store(name, filename, header, content)
+	return open, func() error {
+		delete(s.memoryUploadStore, filename)
+		return nil
+	}, err
+}
+
+func (s *multipartSuite) Test_StreamMultipartRemovesFilesOfFailedBinding(c *C) {
+	store := removableUploadStore{memoryUploadStore{}}
+	binder := &Binder{StreamMultipart: true, UploadStore: store}
+	b, w := makeDocumentPayload()
+	err := binder.MultipartForm().Bind(&struct {
+		Cover *File `form:"cover" binding:"MaxSize(4)"`
+	}{}, newMultipartRequest(b, w.FormDataContentType()))
+
+	c.Assert(err, NotNil)
+	c.Assert(store.memoryUploadStore, HasLen, 0)
+
+	b, w = makeDocumentPayload()
+	err = binder.MultipartForm().Bind(&Document{}, newMultipartRequest(b, w.FormDataContentType()))
+	c.Assert(err, IsNil)
+	c.Assert(store.memoryUploadStore, HasLen, 2)
+}
+
+func (s *multipartSuite) Test_StreamMultipartCapsValues(c *C) {
+	b, w := makeDocumentPayload()
+	binder := &Binder{StreamMultipart: true, MaxMemory: 8}
+	err := binder.MultipartForm().Bind(&Document{}, newMultipartRequest(b, w.FormDataContentType()))
+	c.Assert(err, Equals, ErrorRequestTooLarge)
+
+	b, w = makeDocumentPayload()
+	binder = &Binder{StreamMultipart: true, Limits: Limits{MaxValueLength: 8}}
+	err = binder.MultipartForm().Bind(&Document{}, newMultipartRequest(b, w.FormDataContentType()))
+	c.Assert(err, DeepEquals, Errors{{Classification: LimitExceededError, Message: "MaxValueLength exceeded"}})
+}
+
+func (s *multipartSuite) Test_StreamMultipartWithoutStore(c *C) {
+	document := Document{}
+	b, w := makeDocumentPayload()
+	req := newMultipartRequest(b, w.FormDataContentType())
+	binder := &Binder{StreamMultipart: true}
+	err := binder.MultipartForm().Bind(&document, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(document, DeepEquals, Document{Title: "Glorious Post Title"})
+}
+
+func (s *multipartSuite) Test_DotNotationMultipart(c *C) {
+	blogPost := BlogPost{}
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("author.name", "Matt")
+	writer.WriteField("coauthor.email", "ann@example.com")
+	writer.Close()
+	err := MultipartForm.Bind(&blogPost, newRequest(`POST`, ``, body.String(), writer.FormDataContentType()))
+
+	c.Assert(err, IsNil)
+	c.Assert(blogPost.Author, DeepEquals, Person{Name: "Matt"})
+	c.Assert(blogPost.Coauthor, DeepEquals, &Person{Email: "ann@example.com"})
+}
+
+type UploadWithMetadata struct {
+	Metadata *DocumentedPost       `form:"metadata,json"`
+	Tags     map[string]string     `form:"tags,json"`
+	Document *multipart.FileHeader `form:"document"`
+}
+
+func (s *multipartSuite) Test_JSONPartWithFiles(c *C) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="metadata"`)
+	header.Set("Content-Type", "application/json")
+	part, _ := writer.CreatePart(header)
+	part.Write([]byte(`{"title": "Report", "content": "Quarterly"}`))
+	header = textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="tags"; filename="blob"`)
+	header.Set("Content-Type", "application/json")
+	part, _ = writer.CreatePart(header)
+	part.Write([]byte(`{"year": "2024"}`))
+	file, _ := writer.CreateFormFile("document", "report.pdf")
+	file.Write([]byte("%PDF-1.4"))
+	writer.Close()
+
+	upload := UploadWithMetadata{}
+	err := MultipartForm.Bind(&upload, newRequest(`POST`, ``, body.String(), writer.FormDataContentType()))
+
+	c.Assert(err, IsNil)
+	c.Assert(upload.Metadata, DeepEquals, &DocumentedPost{Title: "Report", Content: "Quarterly"})
+	c.Assert(upload.Tags, DeepEquals, map[string]string{"year": "2024"})
+	c.Assert(upload.Document.Filename, Equals, "report.pdf")
+}
+
+func (s *multipartSuite) Test_JSONPartValidated(c *C) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("metadata", `{"content": "Quarterly"}`)
+	writer.Close()
+
+	upload := UploadWithMetadata{}
+	err := MultipartForm.Bind(&upload, newRequest(`POST`, ``, body.String(), writer.FormDataContentType()))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Metadata.Title"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *multipartSuite) Test_MalformedJSONPart(c *C) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("metadata", `{"title":`)
+	writer.Close()
+
+	upload := UploadWithMetadata{}
+	err := MultipartForm.Bind(&upload, newRequest(`POST`, ``, body.String(), writer.FormDataContentType()))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"metadata"}, Classification: DeserializationError, Message: "Malformed JSON"}})
+	c.Assert(StatusCode(err), Equals, http.StatusBadRequest)
+}
+
+type Application struct {
+	Name   string                  `form:"name"`
+	Resume *multipart.FileHeader   `form:"resume" binding:"Required"`
+	Papers []*multipart.FileHeader `form:"papers[]" binding:"Required"`
+	Photo  *File                   `binding:"Required"`
+}
+
+func (s *multipartSuite) Test_RequiredFileErrors(c *C) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("name", "Matt")
+	part, _ := writer.CreateFormFile("papers[]", "paper.txt")
+	part.Write([]byte("Paper"))
+	writer.Close()
+	binder := &Binder{RequiredFileErrors: true}
+	err := binder.MultipartForm().Bind(&Application{}, newMultipartRequest(body, writer.FormDataContentType()))
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"resume"}, Classification: RequiredFileError, Message: "Required file"},
+		{FieldNames: []string{"photo"}, Classification: RequiredFileError, Message: "Required file"},
+	})
+}
+
+func (s *multipartSuite) Test_FieldSetterClaimsUnknownParts(c *C) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("title", "Glorious")
+	writer.WriteField("field_color", "red")
+	writer.Close()
+	binder := &Binder{UnknownParts: RejectUnknownParts}
+	item := ContentItem{}
+	err := binder.MultipartForm().Bind(&item, newRequest(`POST`, ``, body.String(), writer.FormDataContentType()))
+
+	c.Assert(err, IsNil)
+	c.Assert(item.Fields, DeepEquals, map[string]string{"color": "red"})
+}
@@ -0,0 +1,169 @@
+package binding
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+type ndjsonBinding struct {
+	binder *Binder
+}
+
+func (_ ndjsonBinding) Name() string {
+	return "ndjson"
+}
+
+// NDJSON deserializes a newline delimited JSON payload from the request into
+// the slice that is passed in, one element per record. Every record is
+// validated; the errors of all records are returned together, with the
+// index of the record as the first part of their field names, for example
+// "3.Title". Records that fail to decode are reported as a
+// DeserializationError on their index and left out of the slice.
+func (n ndjsonBinding) Bind(dst interface{}, req *http.Request) (err error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	binder := binderOrDefault(n.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = binder.done(dst, req, n.Name(), err) }()
+
+	v = v.Elem()
+	if v.Kind() != reflect.Slice {
+		return ErrorInputIsNotSlice
+	}
+
+	elemType := v.Type().Elem()
+	newRecord := func() interface{} {
+		if elemType.Kind() == reflect.Ptr {
+			return reflect.New(elemType.Elem()).Interface()
+		}
+		return reflect.New(elemType).Interface()
+	}
+
+	return binder.BindNDJSON(req, newRecord, func(index int, record interface{}) error {
+		value := reflect.ValueOf(record)
+		if elemType.Kind() != reflect.Ptr {
+			value = value.Elem()
+		}
+		v.Set(reflect.Append(v, value))
+		return nil
+	})
+}
+
+// BindNDJSON decodes the newline delimited JSON records of the request one at a
+// time using the default binder, see Binder.BindNDJSON.
+func BindNDJSON(req *http.Request, newRecord func() interface{}, fn func(index int, record interface{}) error) error {
+	return defaultBinder.BindNDJSON(req, newRecord, fn)
+}
+
+// BindNDJSON decodes the newline delimited JSON records of the request one at a
+// time, without buffering the whole body. Each record is decoded into a new
+// value returned by newRecord and validated; fn is called with the records that
+// pass. The errors of the other records are collected, with the index of the
+// record as the first part of their field names, and returned once the body is
+// consumed. An error returned by fn stops the binding and is returned as is.
+func (b *Binder) BindNDJSON(req *http.Request, newRecord func() interface{}, fn func(index int, record interface{}) error) (err error) {
+	defer func() { err = finishBodyChecks(req, err) }()
+
+	var errors Errors
+	err = b.readNDJSON(req, newRecord, func(index int, record interface{}, recordErrors Errors) error {
+		if recordErrors.Len() > 0 {
+			errors, _ = appendRecordErrors(errors, index, recordErrors)
+			return nil
+		}
+		return fn(index, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	if errors.Len() > 0 {
+		return errors
+	}
+	return nil
+}
+
+// readNDJSON reads the records of the newline delimited JSON body one at a
+// time, decodes each into a new value returned by newRecord and validates it.
+// each is called with the record and its errors, or with a nil record for a
+// line that failed to decode. An error returned by each stops the reading and
+// is returned as is.
+func (b *Binder) readNDJSON(req *http.Request, newRecord func() interface{}, each func(index int, record interface{}, recordErrors Errors) error) error {
+	if err := b.checkHeaders(req); err != nil {
+		return err
+	}
+	if req.Body == nil {
+		return nil
+	}
+	stats := requestStats(req)
+	countBody(req, stats)
+	if err := b.decodeBody(req); err != nil {
+		return err
+	}
+	defer req.Body.Close()
+
+	reader := bufio.NewReader(req.Body)
+	for index := 0; ; {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return readError(readErr)
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			stats.Records++
+			record := newRecord()
+			var recordErrors Errors
+			if err := json.Unmarshal(line, record); err != nil {
+				record = nil
+				recordErrors = Errors{{Classification: DeserializationError, Message: ErrorDeserialization.Error()}}
+			} else if err := b.validate(record, req); err != nil {
+				validationErrors, ok := err.(Errors)
+				if !ok {
+					return err
+				}
+				recordErrors = validationErrors
+			}
+			if err := each(index, record, recordErrors); err != nil {
+				return err
+			}
+			index++
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// appendRecordErrors appends the validation errors of the record at index to
+// errors, prefixing their field names with the index. Errors that are not
+// validation errors are returned as is.
+func appendRecordErrors(errors Errors, index int, err error) (Errors, error) {
+	recordErrors, ok := err.(Errors)
+	if !ok {
+		return errors, err
+	}
+
+	for _, recordError := range recordErrors {
+		fieldNames := make([]string, len(recordError.FieldNames))
+		for i, name := range recordError.FieldNames {
+			fieldNames[i] = strconv.Itoa(index) + "." + name
+		}
+		if len(fieldNames) == 0 {
+			//an error about the record as a whole, like a line that failed to decode
+			fieldNames = []string{strconv.Itoa(index)}
+		}
+		recordError.FieldNames = fieldNames
+		errors = append(errors, recordError)
+	}
+	return errors, nil
+}
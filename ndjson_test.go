@@ -0,0 +1,67 @@
+package binding
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type ndjsonSuite struct{}
+
+var _ = Suite(&ndjsonSuite{})
+
+const ndjsonContentType = "application/x-ndjson"
+
+func (s *ndjsonSuite) Test_NDJSON(c *C) {
+	people := []RequiredName{}
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n\n{\"Name\": \"Michael Boke\"}", ndjsonContentType)
+	err := Bind(&people, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(people, DeepEquals, []RequiredName{{Name: "Matt Holt"}, {Name: "Michael Boke"}})
+}
+
+func (s *ndjsonSuite) Test_NDJSONPointers(c *C) {
+	people := []*RequiredName{}
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n", ndjsonContentType)
+	err := Bind(&people, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(people, DeepEquals, []*RequiredName{{Name: "Matt Holt"}})
+}
+
+func (s *ndjsonSuite) Test_NDJSONRecordErrors(c *C) {
+	people := []RequiredName{}
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n{}\n{\"Name\":\n{\"Name\": \"Michael Boke\"}\n", ndjsonContentType)
+	err := Bind(&people, req)
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"1.Name"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"2"}, Classification: DeserializationError, Message: ErrorDeserialization.Error()},
+	})
+	c.Assert(people, DeepEquals, []RequiredName{{Name: "Matt Holt"}, {Name: "Michael Boke"}})
+}
+
+func (s *ndjsonSuite) Test_NDJSONNotASlice(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n", ndjsonContentType)
+	err := Bind(&person, req)
+
+	c.Assert(err, DeepEquals, ErrorInputIsNotSlice)
+}
+
+func (s *ndjsonSuite) Test_BindNDJSONCallback(c *C) {
+	names := []string{}
+	req := newRequest(`POST`, ``, "{\"Name\": \"Matt Holt\"}\n{\"Name\": \"Michael Boke\"}\n{\"Name\": \"Other\"}\n", ndjsonContentType)
+	stop := errors.New("stop")
+	err := BindNDJSON(req, func() interface{} { return &RequiredName{} }, func(index int, record interface{}) error {
+		names = append(names, record.(*RequiredName).Name)
+		if index == 1 {
+			return stop
+		}
+		return nil
+	})
+
+	c.Assert(err, Equals, stop)
+	c.Assert(names, DeepEquals, []string{"Matt Holt", "Michael Boke"})
+}
@@ -0,0 +1,143 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// NonceStore remembers the nonces of accepted requests, so a signed form or
+// webhook delivery can not be replayed. Implementations are expected to
+// expire old nonces and to be safe for concurrent use.
+type NonceStore interface {
+	// Use records nonce and reports whether it was not used before.
+	Use(nonce string) bool
+}
+
+// checkReplay checks the fields of dst tagged with the Timestamp rule against
+// the NonceWindow and then uses the nonces of the fields tagged with the Nonce
+// rule with the NonceStore. Every field is checked before the first nonce is
+// used, so a request with a missing, repeated or expired value uses none.
+func (b *Binder) checkReplay(dst interface{}, options validation) error {
+	if b.dryRun {
+		return nil
+	}
+
+	var errors Errors
+	if b.NonceWindow > 0 {
+		now := options.now()
+		for _, stamp := range ruleFields(nil, reflect.ValueOf(dst), "", "Timestamp") {
+			t, ok := timestampValue(stamp.value)
+			if !ok || t.Before(now.Add(-b.NonceWindow)) || t.After(now.Add(b.NonceWindow)) {
+				errors.Add([]string{stamp.field}, ReplayError, "Expired")
+			}
+		}
+	}
+
+	var nonces []ruleField
+	if b.NonceStore != nil {
+		nonces = ruleFields(nil, reflect.ValueOf(dst), "", "Nonce")
+		seen := map[string]bool{}
+		for _, nonce := range nonces {
+			if value := nonce.value.String(); value == "" || seen[value] {
+				errors.Add([]string{nonce.field}, ReplayError, "Replayed")
+			} else {
+				seen[value] = true
+			}
+		}
+	}
+	if errors.Len() > 0 {
+		return errors
+	}
+
+	for _, nonce := range nonces {
+		if !b.NonceStore.Use(nonce.value.String()) {
+			errors.Add([]string{nonce.field}, ReplayError, "Replayed")
+		}
+	}
+	if errors.Len() > 0 {
+		return errors
+	}
+	return nil
+}
+
+// timestampValue returns the time of a Timestamp field, a time.Time or an
+// integer holding seconds since the Unix epoch.
+func timestampValue(v reflect.Value) (time.Time, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return time.Time{}, false
+		}
+		v = v.Elem()
+	}
+
+	if isIntegerKind(v.Kind()) {
+		if v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr {
+			return time.Unix(int64(v.Uint()), 0), v.Uint() != 0
+		}
+		return time.Unix(v.Int(), 0), v.Int() != 0
+	}
+	return timeValue(v)
+}
+
+type ruleField struct {
+	field string
+	value reflect.Value
+}
+
+// ruleFields collects the fields tagged with rule, following nested structs.
+// Nonce fields are strings, Timestamp fields a time.Time or an integer.
+func ruleFields(fields []ruleField, val reflect.Value, path, rule string) []ruleField {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return fields
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return fields
+	}
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !val.Field(i).CanInterface() {
+			continue
+		}
+
+		fieldPath := path
+		if !field.Anonymous {
+			fieldPath = path + field.Name + "."
+		}
+
+		switch {
+		case hasRule(field.Tag.Get("binding"), rule) && isRuleFieldType(field.Type, rule):
+			fields = append(fields, ruleField{field: path + field.Name, value: val.Field(i)})
+		case field.Type.Kind() == reflect.Struct, field.Type.Kind() == reflect.Ptr:
+			fields = ruleFields(fields, val.Field(i), fieldPath, rule)
+		}
+	}
+	return fields
+}
+
+// isRuleFieldType reports whether a field of type typ can hold the value of rule.
+func isRuleFieldType(typ reflect.Type, rule string) bool {
+	if rule == "Nonce" {
+		return typ.Kind() == reflect.String
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ == timeType || isIntegerKind(typ.Kind())
+}
+
+// hasRule reports whether the binding tag contains rule.
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ";") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package binding
+
+import (
+	"sync"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type nonceSuite struct{}
+
+var _ = Suite(&nonceSuite{})
+
+type memoryNonceStore struct {
+	sync.Mutex
+	used map[string]bool
+}
+
+func (s *memoryNonceStore) Use(nonce string) bool {
+	s.Lock()
+	defer s.Unlock()
+	if s.used[nonce] {
+		return false
+	}
+	s.used[nonce] = true
+	return true
+}
+
+type SignedWebhook struct {
+	Event string `form:"event" json:"event" binding:"Required"`
+	Nonce string `form:"nonce" json:"nonce" binding:"Nonce"`
+}
+
+func (s *nonceSuite) Test_NonceReplay(c *C) {
+	binder := &Binder{NonceStore: &memoryNonceStore{used: map[string]bool{}}}
+
+	webhook := SignedWebhook{}
+	err := binder.Form().Bind(&webhook, newRequest(`POST`, ``, `event=push&nonce=abc`, formContentType))
+	c.Assert(err, IsNil)
+
+	webhook = SignedWebhook{}
+	err = binder.JSON().Bind(&webhook, newRequest(`POST`, ``, `{"event":"push","nonce":"abc"}`, jsonContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Nonce"}, Classification: ReplayError, Message: "Replayed"}})
+}
+
+func (s *nonceSuite) Test_NonceMissing(c *C) {
+	binder := &Binder{NonceStore: &memoryNonceStore{used: map[string]bool{}}}
+	webhook := SignedWebhook{}
+	err := binder.Form().Bind(&webhook, newRequest(`POST`, ``, `event=push`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Nonce"}, Classification: ReplayError, Message: "Replayed"}})
+}
+
+func (s *nonceSuite) Test_NonceNotUsedByInvalidRequest(c *C) {
+	store := &memoryNonceStore{used: map[string]bool{}}
+	binder := &Binder{NonceStore: store}
+	webhook := SignedWebhook{}
+	err := binder.Form().Bind(&webhook, newRequest(`POST`, ``, `nonce=abc`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Event"}, Classification: RequiredError, Message: "Required"}})
+	c.Assert(store.used, HasLen, 0)
+}
+
+func (s *nonceSuite) Test_NonceWithoutStore(c *C) {
+	webhook := SignedWebhook{}
+	err := Form.Bind(&webhook, newRequest(`POST`, ``, `event=push`, formContentType))
+
+	c.Assert(err, IsNil)
+}
+
+type DoublySignedWebhook struct {
+	Nonce      string `form:"nonce" binding:"Nonce"`
+	RetryNonce string `form:"retry_nonce" binding:"Nonce"`
+}
+
+func (s *nonceSuite) Test_NoncesCheckedBeforeUse(c *C) {
+	store := &memoryNonceStore{used: map[string]bool{}}
+	binder := &Binder{NonceStore: store}
+	err := binder.Form().Bind(&DoublySignedWebhook{}, newRequest(`POST`, ``, `nonce=abc`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"RetryNonce"}, Classification: ReplayError, Message: "Replayed"}})
+	c.Assert(store.used, HasLen, 0)
+
+	err = binder.Form().Bind(&DoublySignedWebhook{}, newRequest(`POST`, ``, `nonce=abc&retry_nonce=abc`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"RetryNonce"}, Classification: ReplayError, Message: "Replayed"}})
+	c.Assert(store.used, HasLen, 0)
+}
+
+type TimestampedWebhook struct {
+	Nonce  string    `form:"nonce" binding:"Nonce"`
+	SentAt int64     `form:"sent_at" binding:"Timestamp"`
+	Signed time.Time `form:"signed" binding:"Timestamp"`
+}
+
+func (s *nonceSuite) Test_NonceWindow(c *C) {
+	now := time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC)
+	store := &memoryNonceStore{used: map[string]bool{}}
+	binder := &Binder{NonceStore: store, NonceWindow: 5 * time.Minute, Clock: func() time.Time { return now }}
+
+	err := binder.Form().Bind(&TimestampedWebhook{}, newRequest(`POST`, ``, `nonce=abc&sent_at=1433154600&signed=2015-06-01T10:27:00Z`, formContentType))
+	c.Assert(err, IsNil)
+
+	err = binder.Form().Bind(&TimestampedWebhook{}, newRequest(`POST`, ``, `nonce=def&sent_at=1433154000&signed=2015-06-01T10:36:00Z`, formContentType))
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"SentAt"}, Classification: ReplayError, Message: "Expired"},
+		{FieldNames: []string{"Signed"}, Classification: ReplayError, Message: "Expired"},
+	})
+	c.Assert(store.used["def"], Equals, false)
+
+	err = binder.Form().Bind(&TimestampedWebhook{}, newRequest(`POST`, ``, `nonce=ghi`, formContentType))
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"SentAt"}, Classification: ReplayError, Message: "Expired"},
+		{FieldNames: []string{"Signed"}, Classification: ReplayError, Message: "Expired"},
+	})
+}
@@ -0,0 +1,95 @@
+package binding
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// integerValues applies the float policy of a field, set with the float tag,
+// to the values bound into an integer field of kind. A value with a fraction,
+// like 3.7 from a spreadsheet export, is truncated to 3 with "truncate" and
+// rounded to 4 with "round"; "error" reports it instead of leaving the field
+// zero. The values are returned as is for other kinds or without a policy.
+func integerValues(values []string, policy string, kind reflect.Kind) ([]string, bool) {
+	if policy == "" || !isIntegerKind(kind) {
+		return values, true
+	}
+
+	converted := make([]string, len(values))
+	for i, value := range values {
+		converted[i] = value
+		if value == "" || isInteger(value) {
+			continue
+		}
+
+		number, err := strconv.ParseFloat(value, 64)
+		switch {
+		case err == nil && policy == "truncate":
+			converted[i] = strconv.FormatFloat(math.Trunc(number), 'f', 0, 64)
+		case err == nil && policy == "round":
+			converted[i] = strconv.FormatFloat(math.Round(number), 'f', 0, 64)
+		case policy == "error":
+			return nil, false
+		}
+	}
+	return converted, true
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isInteger(value string) bool {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return true
+	}
+	_, err := strconv.ParseUint(value, 10, 64)
+	return err == nil
+}
+
+// relaxedNumbers rewrites the values bound into an integer field of kind that
+// use digit separators, like 1_000_000, or scientific notation for a whole
+// number, like 1e6, into the plain notation strconv.ParseInt parses. Float
+// fields accept both already. Other values are returned as is.
+func relaxedNumbers(values []string, kind reflect.Kind) []string {
+	if !isIntegerKind(kind) {
+		return values
+	}
+
+	relaxed := make([]string, len(values))
+	for i, value := range values {
+		relaxed[i] = value
+		if strings.Contains(value, "_") && hasDigitSeparators(value) {
+			value = strings.Replace(value, "_", "", -1)
+			relaxed[i] = value
+		}
+		if strings.ContainsAny(value, "eE") {
+			if number, err := strconv.ParseFloat(value, 64); err == nil && number == math.Trunc(number) && math.Abs(number) < 1<<63 {
+				relaxed[i] = strconv.FormatFloat(number, 'f', 0, 64)
+			}
+		}
+	}
+	return relaxed
+}
+
+// hasDigitSeparators reports whether every underscore in value is placed
+// between two digits.
+func hasDigitSeparators(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] == '_' && (i == 0 || i == len(value)-1 || !isDigit(value[i-1]) || !isDigit(value[i+1])) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
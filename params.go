@@ -0,0 +1,388 @@
+package binding
+
+import (
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strings"
+)
+
+// Query binds the fields tagged with query, `query:"page"`, from the query
+// string of the request using the default binder, see Binder.Query.
+func Query(obj interface{}, req *http.Request) error {
+	return defaultBinder.Query(obj, req)
+}
+
+// Query binds the fields tagged with query from the query string of the
+// request and validates obj. The query tag is independent of the form tag, so
+// the query parameters of a request with a JSON or form body never collide
+// with its body. Bind binds the tagged fields before it binds the body.
+func (b *Binder) Query(obj interface{}, req *http.Request) error {
+	return b.bindParameterTag(obj, req, "query")
+}
+
+// Header binds the fields tagged with header, `header:"X-Request-Id"`, from
+// the headers of the request using the default binder, see Binder.Header.
+func Header(obj interface{}, req *http.Request) error {
+	return defaultBinder.Header(obj, req)
+}
+
+// Header binds the fields tagged with header from the headers of the request
+// and validates obj. Header names are case insensitive. A slice receives the
+// values of every header line, with comma separated lists split into their
+// elements. Bind binds the tagged fields before it binds the body.
+func (b *Binder) Header(obj interface{}, req *http.Request) error {
+	return b.bindParameterTag(obj, req, "header")
+}
+
+// Cookie binds the fields tagged with cookie, `cookie:"session_id"`, from the
+// cookies of the request using the default binder, see Binder.Cookie.
+func Cookie(obj interface{}, req *http.Request) error {
+	return defaultBinder.Cookie(obj, req)
+}
+
+// Cookie binds the fields tagged with cookie from the cookies of the request
+// and validates obj. A slice receives the values of every cookie with the
+// name. Bind binds the tagged fields before it binds the body.
+func (b *Binder) Cookie(obj interface{}, req *http.Request) error {
+	return b.bindParameterTag(obj, req, "cookie")
+}
+
+// URI binds the fields tagged with uri, `uri:"id"`, from the path parameters
+// of the request using the default binder, see Binder.URI.
+func URI(obj interface{}, req *http.Request) error {
+	return defaultBinder.URI(obj, req)
+}
+
+// URI binds the fields tagged with uri from the path parameters of the request
+// and validates obj. The parameters are read with the ParamExtractor of the
+// binder, or with Request.PathValue of the net/http ServeMux without one.
+// Bind binds the tagged fields before it binds the body.
+func (b *Binder) URI(obj interface{}, req *http.Request) error {
+	return b.bindParameterTag(obj, req, "uri")
+}
+
+// Auth binds the fields tagged with auth from the Authorization header of the
+// request using the default binder, see Binder.Auth.
+func Auth(obj interface{}, req *http.Request) error {
+	return defaultBinder.Auth(obj, req)
+}
+
+// Auth binds the credentials in the Authorization header of the request into
+// the fields tagged with auth and validates obj: `auth:"bearer"` binds the
+// token of the Bearer scheme, `auth:"basic_user"` and `auth:"basic_pass"` the
+// user and password of the Basic scheme, and `auth:"scheme"` the name of the
+// scheme. Bind binds the tagged fields before it binds the body.
+func (b *Binder) Auth(obj interface{}, req *http.Request) error {
+	return b.bindParameterTag(obj, req, "auth")
+}
+
+// bindParameterTag binds the fields tagged with tag and validates obj.
+func (b *Binder) bindParameterTag(obj interface{}, req *http.Request, tag string) (err error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = b.done(obj, req, tag, err) }()
+
+	v = v.Elem()
+	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		if b.NoPointerAllocation {
+			return ErrorInputIsNilPointer
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return ErrorInputIsNotStructure
+	}
+
+	if errors := b.mapParameters(nil, v, tag, b.parameterLookup(req, tag)); len(errors) > 0 {
+		return errors
+	}
+	return b.validate(obj, req)
+}
+
+// parameterTags are the tags of the fields bound from the request itself
+// instead of from its body.
+var parameterTags = []string{"uri", "query", "header", "cookie", "auth"}
+
+// inSources maps the sources of the in tag, as named by OpenAPI, to the tags
+// of the request parameters.
+var inSources = map[string]string{"path": "uri", "query": "query", "header": "header", "cookie": "cookie"}
+
+// inParameterName returns the name of the parameter bound into a field tagged
+// with in: the name of its json tag, else of its form tag, else the lower case
+// field name.
+func inParameterName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		if name, _ := parseTag(field.Tag.Get(tag)); name != "" && name != "-" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name)
+}
+
+// bodyField returns the field of the struct tagged with `in:"body"`.
+func bodyField(v reflect.Value) (reflect.Value, bool) {
+	v = reflect.Indirect(v)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return reflect.Value{}, false
+	}
+
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).Tag.Get("in") == "body" && v.Field(i).CanSet() {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// bindParameters binds the fields of the struct tagged with one of the request
+// parameter tags and returns the errors of the values that did not convert.
+// Values that are not a struct, like the slices bound by the NDJSON and CSV
+// bindings, are left alone.
+func (b *Binder) bindParameters(v reflect.Value, req *http.Request) Errors {
+	v = reflect.Indirect(v)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return nil
+	}
+
+	var errors Errors
+	for _, tag := range parameterTags {
+		errors = b.mapParameters(errors, v, tag, b.parameterLookup(req, tag))
+	}
+	return errors
+}
+
+// parameterLookup returns the function looking up the values of a parameter
+// of the request by the name in a tag.
+func (b *Binder) parameterLookup(req *http.Request, tag string) func(name string) ([]string, bool) {
+	switch tag {
+	case "uri":
+		if b.ParamExtractor != nil {
+			params := b.ParamExtractor(req)
+			return func(name string) ([]string, bool) {
+				value, exists := params[name]
+				return []string{value}, exists
+			}
+		}
+		return func(name string) ([]string, bool) {
+			value := req.PathValue(name)
+			return []string{value}, value != ""
+		}
+	case "header":
+		return func(name string) ([]string, bool) {
+			values, exists := req.Header[textproto.CanonicalMIMEHeaderKey(name)]
+			return values, exists
+		}
+	case "auth":
+		return func(name string) ([]string, bool) {
+			value, exists := authCredential(req, name)
+			return []string{value}, exists
+		}
+	case "cookie":
+		cookies := req.Cookies()
+		return func(name string) ([]string, bool) {
+			values := []string{}
+			for _, cookie := range cookies {
+				if cookie.Name == name {
+					values = append(values, cookie.Value)
+				}
+			}
+			return values, len(values) > 0
+		}
+	default:
+		query := req.URL.Query()
+		return func(name string) ([]string, bool) {
+			values, exists := query[name]
+			return values, exists
+		}
+	}
+}
+
+// mapParameters sets the fields tagged with tag to the values lookup returns
+// for the name in the tag, looking into nested and embedded structs. Slices
+// receive every value, other fields the first one, or a MultipleValuesError
+// under RejectMultipleValues. A value that does not convert into its field is
+// added to errors, named by the parameter.
+func (b *Binder) mapParameters(errors Errors, v reflect.Value, tag string, lookup func(name string) ([]string, bool)) Errors {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := v.Field(i)
+		if !structField.CanSet() || isUnbindable(typeField.Type) {
+			continue
+		}
+
+		name, _ := parseTag(typeField.Tag.Get(tag))
+		if name == "" && inSources[typeField.Tag.Get("in")] == tag {
+			name = inParameterName(typeField)
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			if typeField.Type.Kind() == reflect.Struct && typeField.Type != timeType {
+				errors = b.mapParameters(errors, structField, tag, lookup)
+			} else if typeField.Type.Kind() == reflect.Ptr && typeField.Type.Elem().Kind() == reflect.Struct && !structField.IsNil() {
+				errors = b.mapParameters(errors, structField.Elem(), tag, lookup)
+			}
+			continue
+		}
+
+		values, exists := lookup(name)
+		if !exists || len(values) == 0 {
+			continue
+		}
+		//a header holds a list as comma separated elements
+		if tag == "header" && (typeField.Type.Kind() == reflect.Slice ||
+			(typeField.Type.Kind() == reflect.Ptr && typeField.Type.Elem().Kind() == reflect.Slice)) {
+			values = splitHeaderList(values)
+		}
+		if len(values) > 1 && b.MultipleValues == RejectMultipleValues && singleValued(typeField.Type) {
+			errors = append(errors, NewFieldError(name, MultipleValuesError, "Multiple values"))
+			continue
+		}
+		if !b.setParameter(values, structField, typeField) {
+			classification, message := conversionError(typeField.Type)
+			errors = append(errors, NewFieldError(name, classification, message))
+		}
+	}
+	return errors
+}
+
+// setParameter converts the values into the type of the field, allocating
+// pointers. Times are parsed like the form bindings do, by the time_format
+// and time_location tags of field. It reports false when a value does not
+// convert; the field is then left as it was.
+func (b *Binder) setParameter(values []string, structField reflect.Value, field reflect.StructField) bool {
+	if structField.Type() == timeType {
+		return b.setTime(values[0], structField, field)
+	}
+	if structField.Type() == bytesType {
+		data, err := decodeBinary(field.Tag.Get("encoding"), values[0])
+		if err != nil {
+			return false
+		}
+		structField.SetBytes(data)
+		return true
+	}
+	if valueType, ok := lookupValueType(structField.Type()); ok {
+		value, err := valueType.parse(values[0])
+		if err != nil {
+			return false
+		}
+		structField.Set(reflect.ValueOf(value))
+		return true
+	}
+	if isTextUnmarshaler(structField.Type()) {
+		return setText(values[0], structField) == nil
+	}
+
+	if structField.Kind() == reflect.Ptr {
+		value := reflect.New(structField.Type().Elem())
+		if !b.setParameter(values, value.Elem(), field) {
+			return false
+		}
+		structField.Set(value)
+		return true
+	}
+
+	if structField.Type() == pointType {
+		point, err := ParsePoint(values[0])
+		if err != nil {
+			return false
+		}
+		structField.Set(reflect.ValueOf(point))
+		return true
+	}
+
+	if structField.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(structField.Type(), len(values), len(values))
+		for i, value := range values {
+			if !b.setParameter([]string{value}, slice.Index(i), field) {
+				return false
+			}
+		}
+		structField.Set(slice)
+		return true
+	}
+	return setWithProperType(structField.Kind(), values[0], structField, "")
+}
+
+// conversionError returns the classification and message of a value that
+// does not convert into a field of type typ, the same the form binding uses.
+func conversionError(typ reflect.Type) (string, string) {
+	for {
+		if valueType, ok := lookupValueType(typ); ok {
+			return valueType.classification, valueType.message
+		}
+		switch {
+		case typ == bytesType:
+			return EncodingError, "Invalid encoding"
+		case typ == durationType:
+			return DurationError, "Invalid duration"
+		case typ == timeType:
+			return TimeTypeError, "Invalid time"
+		case typ == pointType:
+			return DeserializationError, "Malformed point"
+		case isTextUnmarshaler(typ):
+			return DeserializationError, "Malformed value"
+		case typ.Kind() == reflect.Ptr, typ.Kind() == reflect.Slice:
+			typ = typ.Elem()
+			continue
+		case isIntegerKind(typ.Kind()):
+			return IntegerTypeError, "Not an integer"
+		case typ.Kind() == reflect.Bool:
+			return BooleanTypeError, "Not a boolean"
+		case typ.Kind() == reflect.Float32, typ.Kind() == reflect.Float64:
+			return FloatTypeError, "Not a number"
+		}
+		return DeserializationError, "Malformed value"
+	}
+}
+
+// splitHeaderList splits the comma separated elements of the header values.
+func splitHeaderList(values []string) []string {
+	elements := []string{}
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				elements = append(elements, element)
+			}
+		}
+	}
+	return elements
+}
+
+// authCredential returns the part of the Authorization header named by the
+// auth tag of a field.
+func authCredential(req *http.Request, name string) (string, bool) {
+	scheme, credentials, _ := strings.Cut(strings.TrimSpace(req.Header.Get("Authorization")), " ")
+	switch name {
+	case "scheme":
+		return scheme, scheme != ""
+	case "bearer":
+		token := strings.TrimSpace(credentials)
+		return token, strings.EqualFold(scheme, "Bearer") && token != ""
+	case "basic_user":
+		user, _, ok := req.BasicAuth()
+		return user, ok
+	case "basic_pass":
+		_, password, ok := req.BasicAuth()
+		return password, ok
+	}
+	return "", false
+}
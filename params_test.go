@@ -0,0 +1,314 @@
+package binding
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type paramsSuite struct{}
+
+var _ = Suite(&paramsSuite{})
+
+type Paging struct {
+	Page    int  `query:"page" binding:"Range(1,1000)"`
+	PerPage *int `query:"per_page"`
+}
+
+type PostSearch struct {
+	Paging
+	Title string   `json:"title" form:"title"`
+	Tags  []string `query:"tag"`
+	Sort  string   `query:"-"`
+}
+
+func (s *paramsSuite) Test_Query(c *C) {
+	search := PostSearch{}
+	req := newRequest(`GET`, `/posts?page=2&per_page=50&tag=go&tag=web&title=ignored&Sort=title`, ``, ``)
+	err := Query(&search, req)
+
+	perPage := 50
+	c.Assert(err, IsNil)
+	c.Assert(search, DeepEquals, PostSearch{Paging: Paging{Page: 2, PerPage: &perPage}, Tags: []string{"go", "web"}})
+}
+
+func (s *paramsSuite) Test_QueryIsValidated(c *C) {
+	err := Query(&PostSearch{}, newRequest(`GET`, `/posts?page=0`, ``, ``))
+
+	c.Assert(err, NotNil)
+	c.Assert(err.(Errors).Has(RangeError), Equals, true)
+}
+
+func (s *paramsSuite) Test_BindQueryWithJSONBody(c *C) {
+	search := PostSearch{}
+	req := newRequest(`POST`, `/posts?page=3&title=query`, `{"title": "body"}`, jsonContentType)
+	err := Bind(&search, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(search, DeepEquals, PostSearch{Paging: Paging{Page: 3}, Title: "body"})
+}
+
+func (s *paramsSuite) Test_BindQueryWithFormBody(c *C) {
+	search := PostSearch{}
+	req := newRequest(`POST`, `/posts?page=3`, `title=body&page=9`, formContentType)
+	err := Bind(&search, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(search, DeepEquals, PostSearch{Paging: Paging{Page: 3}, Title: "body"})
+}
+
+type RequestMeta struct {
+	RequestId string   `header:"X-Request-Id" binding:"Required"`
+	Retries   int      `header:"x-retry-count"`
+	Accept    []string `header:"Accept"`
+}
+
+type CreatePostRequest struct {
+	RequestMeta
+	Title string `json:"title"`
+}
+
+func (s *paramsSuite) Test_Header(c *C) {
+	meta := RequestMeta{}
+	req := newRequest(`GET`, `/posts`, ``, ``)
+	req.Header.Set("X-Request-Id", "f00b4r")
+	req.Header.Set("X-Retry-Count", "2")
+	req.Header.Add("Accept", "application/json, text/plain")
+	req.Header.Add("Accept", "*/*")
+	err := Header(&meta, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(meta, DeepEquals, RequestMeta{RequestId: "f00b4r", Retries: 2, Accept: []string{"application/json", "text/plain", "*/*"}})
+}
+
+func (s *paramsSuite) Test_HeaderIsValidated(c *C) {
+	err := Header(&RequestMeta{}, newRequest(`GET`, `/posts`, ``, ``))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"RequestId"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *paramsSuite) Test_BindHeaderWithJSONBody(c *C) {
+	post := CreatePostRequest{}
+	req := newRequest(`POST`, `/posts`, `{"title": "Glorious Post Title"}`, jsonContentType)
+	req.Header.Set("X-Request-Id", "f00b4r")
+	err := Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, CreatePostRequest{RequestMeta: RequestMeta{RequestId: "f00b4r"}, Title: "Glorious Post Title"})
+}
+
+type Preferences struct {
+	SessionId string `cookie:"session_id" binding:"Required;AlphaDash"`
+	Dark      bool   `cookie:"dark_mode"`
+	PageSize  uint   `cookie:"page_size"`
+}
+
+func (s *paramsSuite) Test_Cookie(c *C) {
+	preferences := Preferences{}
+	req := newRequest(`GET`, `/posts`, ``, ``)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "f00-b4r"})
+	req.AddCookie(&http.Cookie{Name: "dark_mode", Value: "true"})
+	req.AddCookie(&http.Cookie{Name: "page_size", Value: "25"})
+	err := Cookie(&preferences, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(preferences, DeepEquals, Preferences{SessionId: "f00-b4r", Dark: true, PageSize: 25})
+}
+
+func (s *paramsSuite) Test_CookieIsValidated(c *C) {
+	req := newRequest(`POST`, `/posts`, `dark_mode=true`, formContentType)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "f00.b4r"})
+	err := Bind(&Preferences{}, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"SessionId"}, Classification: AlphaDashError, Message: "AlphaDash"}})
+}
+
+type ThingRequest struct {
+	Id    int64  `uri:"id" binding:"Range(1,1000)"`
+	Slug  string `uri:"slug"`
+	Title string `json:"title"`
+}
+
+func (s *paramsSuite) Test_URIWithPathValue(c *C) {
+	thing := ThingRequest{}
+	req := newRequest(`PUT`, `/things/42/glorious-thing`, `{"title": "Glorious Thing"}`, jsonContentType)
+	req.SetPathValue("id", "42")
+	req.SetPathValue("slug", "glorious-thing")
+	err := Bind(&thing, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(thing, DeepEquals, ThingRequest{Id: 42, Slug: "glorious-thing", Title: "Glorious Thing"})
+}
+
+func (s *paramsSuite) Test_URIWithParamExtractor(c *C) {
+	binder := &Binder{ParamExtractor: func(req *http.Request) map[string]string {
+		return map[string]string{"id": "4200"}
+	}}
+	thing := ThingRequest{}
+	err := binder.URI(&thing, newRequest(`GET`, `/things/4200`, ``, ``))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Id"}, Classification: RangeError, Message: "Range"}})
+	c.Assert(thing.Id, Equals, int64(4200))
+}
+
+type UpdateThingBody struct {
+	Title string   `json:"title" form:"title" binding:"Required"`
+	Tags  []string `json:"tags" form:"tag"`
+}
+
+type UpdateThingRequest struct {
+	Id        int64           `in:"path" binding:"Range(1,1000)"`
+	DryRun    bool            `json:"dry_run" in:"query"`
+	RequestId string          `json:"X-Request-Id" in:"header" binding:"Required"`
+	Body      UpdateThingBody `in:"body"`
+}
+
+func (s *paramsSuite) Test_In(c *C) {
+	thing := UpdateThingRequest{}
+	req := newRequest(`PUT`, `/things/42?dry_run=true&title=query`, `{"title": "Glorious Thing", "tags": ["go"], "dry_run": false}`, jsonContentType)
+	req.SetPathValue("id", "42")
+	req.Header.Set("X-Request-Id", "f00b4r")
+	err := Bind(&thing, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(thing, DeepEquals, UpdateThingRequest{
+		Id:        42,
+		DryRun:    true,
+		RequestId: "f00b4r",
+		Body:      UpdateThingBody{Title: "Glorious Thing", Tags: []string{"go"}},
+	})
+}
+
+func (s *paramsSuite) Test_InCombinesErrors(c *C) {
+	req := newRequest(`PUT`, `/things/0`, `tag=go`, formContentType)
+	req.SetPathValue("id", "0")
+	err := Bind(&UpdateThingRequest{}, req)
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Id"}, Classification: RangeError, Message: "Range"},
+		{FieldNames: []string{"RequestId"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Body.Title"}, Classification: RequiredError, Message: "Required"},
+	})
+}
+
+func (s *paramsSuite) Test_InBodyMalformed(c *C) {
+	err := Bind(&UpdateThingRequest{}, newRequest(`PUT`, `/things/1`, `{"title": `, jsonContentType))
+
+	c.Assert(err, Equals, ErrorDeserialization)
+}
+
+type BearerCredentials struct {
+	Scheme string `auth:"scheme"`
+	Token  string `auth:"bearer" binding:"Required;MinSize(8)"`
+}
+
+type BasicCredentials struct {
+	User     string `auth:"basic_user" binding:"Required;AlphaDash"`
+	Password string `auth:"basic_pass" binding:"Required"`
+}
+
+func (s *paramsSuite) Test_AuthBearer(c *C) {
+	credentials := BearerCredentials{}
+	req := newRequest(`GET`, `/posts`, ``, ``)
+	req.Header.Set("Authorization", "Bearer f00b4r-t0k3n")
+	err := Auth(&credentials, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(credentials, DeepEquals, BearerCredentials{Scheme: "Bearer", Token: "f00b4r-t0k3n"})
+
+	req.Header.Set("Authorization", "Bearer f00")
+	err = Auth(&BearerCredentials{}, req)
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Token"}, Classification: MinSizeError, Message: "MinSize"}})
+}
+
+func (s *paramsSuite) Test_AuthBasic(c *C) {
+	credentials := BasicCredentials{}
+	req := newRequest(`POST`, `/posts`, `{}`, jsonContentType)
+	req.SetBasicAuth("matt", "s3cr3t")
+	err := Bind(&credentials, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(credentials, DeepEquals, BasicCredentials{User: "matt", Password: "s3cr3t"})
+
+	req = newRequest(`GET`, `/posts`, ``, ``)
+	req.Header.Set("Authorization", "Bearer f00b4r-t0k3n")
+	err = Auth(&BasicCredentials{}, req)
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"User"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Password"}, Classification: RequiredError, Message: "Required"},
+	})
+}
+
+type ClientFilter struct {
+	Page     int       `query:"page"`
+	Verbose  bool      `query:"verbose"`
+	Since    time.Time `query:"since"`
+	Source   net.IP    `header:"X-Forwarded-For"`
+	Ratio    *float64  `cookie:"ratio"`
+	Sessions []int     `query:"session"`
+}
+
+func (s *paramsSuite) Test_ParameterConversionErrors(c *C) {
+	filter := ClientFilter{}
+	req := newRequest(`GET`, `/clients?page=abc&verbose=maybe&since=yesterday&session=1&session=two`, ``, ``)
+	req.Header.Set("X-Forwarded-For", "10.0.0.300")
+	req.AddCookie(&http.Cookie{Name: "ratio", Value: "half"})
+
+	c.Assert(Query(&filter, req), DeepEquals, Errors{
+		{FieldNames: []string{"page"}, Classification: IntegerTypeError, Message: "Not an integer"},
+		{FieldNames: []string{"verbose"}, Classification: BooleanTypeError, Message: "Not a boolean"},
+		{FieldNames: []string{"since"}, Classification: TimeTypeError, Message: "Invalid time"},
+		{FieldNames: []string{"session"}, Classification: IntegerTypeError, Message: "Not an integer"},
+	})
+	c.Assert(filter.Page, Equals, 0)
+	c.Assert(filter.Sessions, IsNil)
+
+	c.Assert(Header(&filter, req), DeepEquals, Errors{{FieldNames: []string{"X-Forwarded-For"}, Classification: IPError, Message: "Invalid IP address"}})
+	c.Assert(Cookie(&filter, req), DeepEquals, Errors{{FieldNames: []string{"ratio"}, Classification: FloatTypeError, Message: "Not a number"}})
+	c.Assert(filter.Ratio, IsNil)
+}
+
+func (s *paramsSuite) Test_BindReturnsParameterErrors(c *C) {
+	req := newRequest(`PUT`, `/things/abc`, `{"title": "Glorious Thing"}`, jsonContentType)
+	req.SetPathValue("id", "abc")
+	err := Bind(&ThingRequest{}, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"id"}, Classification: IntegerTypeError, Message: "Not an integer"}})
+}
+
+func (s *paramsSuite) Test_ParameterMultipleValues(c *C) {
+	search := PostSearch{}
+	req := newRequest(`GET`, `/posts?page=1&page=2&tag=go&tag=web`, ``, ``)
+	err := StrictAPI().Query(&search, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"page"}, Classification: MultipleValuesError, Message: "Multiple values"}})
+
+	search = PostSearch{}
+	err = Query(&search, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(search.Page, Equals, 1)
+	c.Assert(search.Tags, DeepEquals, []string{"go", "web"})
+}
+
+type ReportRange struct {
+	Since time.Time  `query:"since" time_format:"unixmilli"`
+	Day   time.Time  `query:"day" time_format:"02/01/2006" time_location:"Europe/Amsterdam"`
+	Until *time.Time `query:"until"`
+}
+
+func (s *paramsSuite) Test_ParameterTimes(c *C) {
+	report := ReportRange{}
+	req := newRequest(`GET`, `/reports?since=1600000000000&day=01/06/2015&until=2015-06-01T10:30`, ``, ``)
+	binder := &Binder{TimeLocation: time.FixedZone("UTC-5", -5*60*60), TimeUTC: true}
+	err := binder.Query(&report, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(report.Since, DeepEquals, time.UnixMilli(1600000000000).UTC())
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+	c.Assert(report.Day, DeepEquals, time.Date(2015, 6, 1, 0, 0, 0, 0, amsterdam).UTC())
+	c.Assert(report.Until, NotNil)
+	c.Assert(*report.Until, DeepEquals, time.Date(2015, 6, 1, 15, 30, 0, 0, time.UTC))
+}
@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// PreValidate binds and validates only the fields of obj that can be checked
+// without reading the request body: the fields taking their value from the
+// query string with the query tag option, `form:"name,query"`, and the fields
+// tagged with uri, query, header, cookie or auth, or with the matching in tag.
+// It lets a server reject a request before consuming a large payload, and
+// complete the binding with Bind afterwards. The returned Errors are nil when
+// those fields pass.
+func PreValidate(obj interface{}, req *http.Request) Errors {
+	return defaultBinder.PreValidate(obj, req)
+}
+
+// PreValidate works like the package level PreValidate using the options of
+// this binder, like its Clock, ContextValues and WireFieldNames. The Unique,
+// Exists and Nonce rules are left for Bind.
+func (b *Binder) PreValidate(obj interface{}, req *http.Request) Errors {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return toErrors(ErrorInputNotByReference)
+	}
+	if req == nil {
+		return toErrors(ErrorNilRequest)
+	}
+
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return toErrors(ErrorInputIsNotStructure)
+	}
+
+	if err := b.checkHeaders(req); err != nil {
+		return toErrors(err)
+	}
+
+	mapper := newFormMapper(b, req.URL.Query(), nil)
+	mapper.query = mapper.form
+	mapper.body = map[string][]string{}
+	mapper.defaults = mapper.body
+	if err := mapper.mapStruct(v); err != nil {
+		return toErrors(err)
+	}
+	if errors := b.bindParameters(v, req); len(errors) > 0 {
+		return errors
+	}
+
+	options := b.validationOptions(req, b.ruleContext(req))
+	paths := preValidatedFields(map[string]bool{}, v.Type(), "", options)
+	var errors Errors
+	for _, err := range toErrors(validateWith(obj, options)) {
+		for _, name := range err.FieldNames {
+			if paths[name] {
+				errors = append(errors, err)
+				break
+			}
+		}
+	}
+	return errors
+}
+
+// preValidatedFields collects the paths, as used in the field names of
+// validation errors, of the fields that PreValidate checks.
+func preValidatedFields(paths map[string]bool, typ reflect.Type, path string, options validation) map[string]bool {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if isParameterField(field) {
+			paths[path+options.fieldName(field)] = true
+		} else if fieldType.Kind() == reflect.Struct && fieldType != timeType {
+			fieldPath := path
+			if !field.Anonymous {
+				fieldPath = path + options.fieldName(field) + "."
+			}
+			preValidatedFields(paths, fieldType, fieldPath, options)
+		}
+	}
+	return paths
+}
+
+// isParameterField reports whether a field is bound from the request instead
+// of from its body.
+func isParameterField(field reflect.StructField) bool {
+	if _, options := parseTag(field.Tag.Get("form")); options.Has("query") {
+		return true
+	}
+	if _, ok := inSources[field.Tag.Get("in")]; ok {
+		return true
+	}
+	for _, tag := range parameterTags {
+		if name, _ := parseTag(field.Tag.Get(tag)); name != "" && name != "-" {
+			return true
+		}
+	}
+	return false
+}
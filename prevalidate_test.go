@@ -0,0 +1,93 @@
+package binding
+
+import (
+	"net/http"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type prevalidateSuite struct{}
+
+var _ = Suite(&prevalidateSuite{})
+
+type Upload struct {
+	Bucket string `form:"bucket,query" binding:"Required;AlphaDash"`
+	Title  string `form:"title" binding:"Required"`
+	Target UploadTarget
+}
+
+type UploadTarget struct {
+	Region string `form:"region,query" binding:"In(eu,us)"`
+}
+
+func (s *prevalidateSuite) Test_PreValidate(c *C) {
+	upload := Upload{}
+	req := newRequest(`POST`, `?bucket=photos&target.region=eu`, `title=Holiday`, formContentType)
+	errs := PreValidate(&upload, req)
+
+	c.Assert(errs, IsNil)
+	c.Assert(upload, DeepEquals, Upload{Bucket: "photos", Target: UploadTarget{Region: "eu"}})
+
+	err := Form.Bind(&upload, req)
+	c.Assert(err, IsNil)
+	c.Assert(upload.Title, Equals, "Holiday")
+}
+
+func (s *prevalidateSuite) Test_PreValidateErrors(c *C) {
+	upload := Upload{}
+	req := newRequest(`POST`, `?target.region=asia`, `title=Holiday`, formContentType)
+	errs := PreValidate(&upload, req)
+
+	c.Assert(errs, DeepEquals, Errors{
+		{FieldNames: []string{"Bucket"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Target.Region"}, Classification: InError, Message: "In"},
+	})
+	c.Assert(req.PostForm, IsNil)
+}
+
+func (s *prevalidateSuite) Test_PreValidateNotByReference(c *C) {
+	errs := PreValidate(Upload{}, newRequest(`GET`, ``, ``, ``))
+
+	c.Assert(errs, DeepEquals, Errors{{Classification: InternalError, Message: ErrorInputNotByReference.Error()}})
+}
+
+func (s *prevalidateSuite) Test_PreValidateNilRequest(c *C) {
+	errs := PreValidate(&Upload{}, nil)
+
+	c.Assert(errs, DeepEquals, Errors{{Classification: InternalError, Message: ErrorNilRequest.Error()}})
+}
+
+type ScheduledUpload struct {
+	Bucket    string    `uri:"bucket" binding:"Required;AlphaDash"`
+	RequestId string    `header:"X-Request-Id" binding:"Required"`
+	Session   string    `cookie:"session" binding:"Required"`
+	PublishAt time.Time `query:"publish_at" binding:"After(now)"`
+	Title     string    `json:"title" binding:"Required"`
+}
+
+func (s *prevalidateSuite) Test_PreValidateParameterTags(c *C) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	binder := &Binder{Clock: func() time.Time { return now }}
+	upload := ScheduledUpload{}
+	req := newRequest(`POST`, `/uploads/photos?publish_at=2026-10-16T09:00:00Z`, `{"title": "Holiday"}`, jsonContentType)
+	req.SetPathValue("bucket", "photos")
+	req.Header.Set("X-Request-Id", "f00b4r")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3ss10n"})
+
+	c.Assert(binder.PreValidate(&upload, req), IsNil)
+	c.Assert(upload.Bucket, Equals, "photos")
+	c.Assert(upload.Title, Equals, "")
+
+	req = newRequest(`POST`, `/uploads/photos.bak?publish_at=2026-10-14T09:00:00Z`, `{"title": "Holiday"}`, jsonContentType)
+	req.SetPathValue("bucket", "photos.bak")
+	c.Assert(binder.PreValidate(&ScheduledUpload{}, req), DeepEquals, Errors{
+		{FieldNames: []string{"Bucket"}, Classification: AlphaDashError, Message: "AlphaDash"},
+		{FieldNames: []string{"RequestId"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Session"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"PublishAt"}, Classification: AfterError, Message: "After"},
+	})
+
+	req = newRequest(`POST`, `/uploads/photos?publish_at=tomorrow`, ``, ``)
+	c.Assert(binder.PreValidate(&ScheduledUpload{}, req), DeepEquals, Errors{{FieldNames: []string{"publish_at"}, Classification: TimeTypeError, Message: "Invalid time"}})
+}
@@ -0,0 +1,21 @@
+package binding
+
+import "net/http"
+
+type protobufBinding struct {
+	binder *Binder
+}
+
+func (_ protobufBinding) Name() string {
+	return "protobuf"
+}
+
+// Protobuf deserializes a protocol buffers payload from the request into the
+// proto.Message that is passed in and validates it like the JSON binding does.
+// This package does not depend on a protobuf library; the binding decodes with
+// the ProtobufUnmarshal function of the binder and fails with
+// ErrorUnsupportedContentType when none is set.
+func (p protobufBinding) Bind(dst interface{}, req *http.Request) error {
+	binder := binderOrDefault(p.binder)
+	return binder.unmarshalBody(dst, req, p.Name(), binder.ProtobufUnmarshal)
+}
@@ -0,0 +1,58 @@
+package binding
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type protobufSuite struct{}
+
+var _ = Suite(&protobufSuite{})
+
+// unmarshalName stands in for proto.Unmarshal, it decodes the whole payload into the name.
+func unmarshalName(data []byte, v interface{}) error {
+	if data[0] == 0xff {
+		return errors.New("invalid wire type")
+	}
+	v.(*RequiredName).Name = string(data)
+	return nil
+}
+
+func (s *protobufSuite) Test_Protobuf(c *C) {
+	for _, contentType := range []string{"application/x-protobuf", "application/protobuf"} {
+		person := RequiredName{}
+		req := newRequest(`POST`, ``, `Matt Holt`, contentType)
+		binder := &Binder{ProtobufUnmarshal: unmarshalName}
+		err := binder.Bind(&person, req)
+
+		c.Assert(err, IsNil)
+		c.Assert(person, DeepEquals, RequiredName{Name: "Matt Holt"})
+	}
+}
+
+func (s *protobufSuite) Test_ProtobufValidation(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, ``, "application/x-protobuf")
+	binder := &Binder{ProtobufUnmarshal: unmarshalName}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *protobufSuite) Test_ProtobufMalformed(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, "\xff", "application/x-protobuf")
+	binder := &Binder{ProtobufUnmarshal: unmarshalName}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *protobufSuite) Test_ProtobufWithoutUnmarshal(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `Matt Holt`, "application/x-protobuf")
+	err := Bind(&person, req)
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedContentType)
+}
@@ -0,0 +1,58 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+var (
+	rawModelsMutex sync.RWMutex
+	rawModels      = map[string]reflect.Type{}
+)
+
+// RegisterRawModel registers the struct type of model under name, for the
+// json.RawMessage fields tagged with `raw_model:"name"`. Such a field is left
+// as raw JSON while binding; validation decodes it into a fresh value of the
+// model and validates that value, so plugin specific configuration is checked
+// by its own rules. Registering a name again replaces the model.
+func RegisterRawModel(name string, model interface{}) {
+	typ := reflect.TypeOf(model)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	rawModelsMutex.Lock()
+	defer rawModelsMutex.Unlock()
+	rawModels[name] = typ
+}
+
+// rawModel returns the struct type registered under name.
+func rawModel(name string) (reflect.Type, bool) {
+	rawModelsMutex.RLock()
+	defer rawModelsMutex.RUnlock()
+	typ, ok := rawModels[name]
+	return typ, ok
+}
+
+// validateRawModel decodes the raw JSON of a field into the model registered
+// under name and validates it, with the field as path of the errors. Empty and
+// null documents are left to the rules of the field itself.
+func validateRawModel(errors Errors, name string, data []byte, path string, options validation) Errors {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return errors
+	}
+
+	typ, ok := rawModel(name)
+	if !ok {
+		return append(errors, NewFieldError(path, DeserializationError, "Unknown model "+name))
+	}
+
+	model := reflect.New(typ)
+	if err := json.Unmarshal(data, model.Interface()); err != nil {
+		return append(errors, NewFieldError(path, DeserializationError, "Malformed JSON"))
+	}
+	return validateStruct(errors, model, path+".", options)
+}
@@ -0,0 +1,71 @@
+package binding
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WriteErrors writes an error returned by one of the bindings to the response
+// with the status code suggested by StatusCode, as XML when the Accept header
+// of the request prefers an XML media type and as JSON otherwise.
+func WriteErrors(w http.ResponseWriter, req *http.Request, err error) error {
+	errors := toErrors(err)
+	if errors == nil {
+		errors = Errors{}
+	}
+
+	if acceptsXML(req.Header.Get("Accept")) {
+		data, err := xml.Marshal(errors)
+		if err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", MIMEXML+"; charset=utf-8")
+		w.WriteHeader(errors.Status())
+		_, err = w.Write(append([]byte(xml.Header), data...))
+		return err
+	}
+
+	data, err := json.Marshal(errors)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(errors.Status())
+	_, err = w.Write(data)
+	return err
+}
+
+// acceptsXML reports whether the Accept header gives an XML media type a
+// higher quality than any JSON media type.
+func acceptsXML(accept string) bool {
+	xmlQuality, jsonQuality := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		switch {
+		case strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml"):
+			if quality > xmlQuality {
+				xmlQuality = quality
+			}
+		case strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json"):
+			if quality > jsonQuality {
+				jsonQuality = quality
+			}
+		}
+	}
+	return xmlQuality > 0 && xmlQuality > jsonQuality
+}
@@ -0,0 +1,57 @@
+package binding
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+
+	. "gopkg.in/check.v1"
+)
+
+type renderSuite struct{}
+
+var _ = Suite(&renderSuite{})
+
+func (s *renderSuite) Test_MarshalXML(c *C) {
+	errs := Errors{
+		{FieldNames: []string{"title"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"start", "end"}, Classification: RangeError, Message: "End < start"},
+		{Classification: SpamError, Message: "Spam", Severity: SeverityWarning},
+	}
+	data, err := xml.Marshal(errs)
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `<errors><error field="title" class="RequiredError">Required</error>`+
+		`<error field="start,end" class="RangeError">End &lt; start</error>`+
+		`<error class="SpamError" severity="warning">Spam</error></errors>`)
+}
+
+func (s *renderSuite) Test_WriteErrorsXML(c *C) {
+	req := newRequest(`POST`, ``, ``, formContentType)
+	req.Header.Set("Accept", "application/json;q=0.5, application/xml")
+	recorder := httptest.NewRecorder()
+	err := WriteErrors(recorder, req, Errors{{FieldNames: []string{"title"}, Classification: RequiredError, Message: "Required"}})
+
+	c.Assert(err, IsNil)
+	c.Assert(recorder.Code, Equals, http.StatusUnprocessableEntity)
+	c.Assert(recorder.Header().Get("Content-Type"), Equals, "application/xml; charset=utf-8")
+	c.Assert(recorder.Body.String(), Equals, xml.Header+`<errors><error field="title" class="RequiredError">Required</error></errors>`)
+}
+
+func (s *renderSuite) Test_WriteErrorsJSON(c *C) {
+	req := newRequest(`POST`, ``, ``, formContentType)
+	recorder := httptest.NewRecorder()
+	err := WriteErrors(recorder, req, ErrorDeserialization)
+
+	c.Assert(err, IsNil)
+	c.Assert(recorder.Code, Equals, http.StatusBadRequest)
+	c.Assert(recorder.Header().Get("Content-Type"), Equals, jsonContentType)
+	c.Assert(recorder.Body.String(), Equals, `[{"classification":"DeserializationError","message":"Deserialization error"}]`)
+}
+
+func (s *renderSuite) Test_AcceptsXML(c *C) {
+	c.Assert(acceptsXML("text/html, application/xml;q=0.9, */*;q=0.8"), Equals, true)
+	c.Assert(acceptsXML("application/json, application/xml"), Equals, false)
+	c.Assert(acceptsXML("application/xml;q=0"), Equals, false)
+	c.Assert(acceptsXML(""), Equals, false)
+}
@@ -0,0 +1,74 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// RuleFunc checks the value of a field against a custom rule. The context
+// carries the values of the request, like the current user or tenant, and
+// those of the ContextValues providers of the binder; arg is the argument of
+// the rule, "photos" for `binding:"MaxSizeByPlan(photos)"`, or empty.
+type RuleFunc func(ctx context.Context, value interface{}, arg string) bool
+
+// ValueProvider returns a value of the request to add to the context of the
+// custom rules.
+type ValueProvider func(req *http.Request) interface{}
+
+type customRule struct {
+	check          RuleFunc
+	classification string
+}
+
+var (
+	rulesMutex sync.RWMutex
+	rules      = map[string]customRule{}
+)
+
+// RegisterRule registers a custom rule under name for the binding tag, like
+// `binding:"MaxSizeByPlan(photos)"`. A value that does not pass the rule is
+// reported with the classification and the name of the rule as message. The
+// built in rules take precedence over custom rules with the same name.
+func RegisterRule(name, classification string, rule RuleFunc) {
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+	rules[name] = customRule{check: rule, classification: classification}
+}
+
+// lookupRule returns the custom rule of a binding tag rule, with its argument.
+func lookupRule(rule string) (string, customRule, string, bool) {
+	name, arg := rule, ""
+	if open := strings.IndexByte(rule, '('); open > 0 && strings.HasSuffix(rule, ")") {
+		name, arg = rule[:open], rule[open+1:len(rule)-1]
+	}
+
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+	custom, ok := rules[name]
+	return name, custom, arg, ok
+}
+
+// ruleContext returns the context of the custom rules: the context of the
+// request with the values of the providers added under their keys.
+func (b *Binder) ruleContext(req *http.Request) context.Context {
+	if req == nil {
+		return context.Background()
+	}
+
+	ctx := req.Context()
+	for key, provider := range b.ContextValues {
+		ctx = context.WithValue(ctx, key, provider(req))
+	}
+	return ctx
+}
+
+// context returns the context of the custom rules, nil uses the background
+// context.
+func (o validation) context() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+	return o.ctx
+}
@@ -0,0 +1,27 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+)
+
+// validateShadow evaluates the rules in the shadow tags of dst and passes
+// their violations to the OnShadow callback of the binder. The violations
+// never reach the caller of the binding.
+func (b *Binder) validateShadow(dst interface{}, req *http.Request, ctx context.Context) {
+	if b.OnShadow == nil || b.dryRun {
+		return
+	}
+	violations := validateStruct(nil, reflect.ValueOf(dst), "", validation{
+		workers:       b.ValidationWorkers,
+		wireNames:     b.WireFieldNames,
+		requiredFiles: b.RequiredFileErrors,
+		clock:         b.Clock,
+		ctx:           ctx,
+		shadow:        true,
+	})
+	if len(violations) > 0 {
+		b.OnShadow(req, violations)
+	}
+}
@@ -0,0 +1,53 @@
+package binding
+
+import (
+	"net/http"
+
+	. "gopkg.in/check.v1"
+)
+
+type shadowSuite struct{}
+
+var _ = Suite(&shadowSuite{})
+
+type Signup struct {
+	Username string `json:"username" binding:"Required" shadow:"Required;MinSize(4);AlphaDash"`
+	Password string `json:"password" binding:"Required;MinSize(6)" shadow:"MinSize(12)"`
+	Country  string `json:"country" transform:"upper" shadow:"Default(NL);Required"`
+}
+
+func (s *shadowSuite) Test_ShadowRules(c *C) {
+	var reported Errors
+	binder := &Binder{OnShadow: func(req *http.Request, violations Errors) {
+		c.Assert(req, NotNil)
+		reported = append(reported, violations...)
+	}}
+	signup := Signup{}
+	req := newRequest(`POST`, ``, `{"username": "ab", "password": "s3cr3t", "country": "nl"}`, jsonContentType)
+	err := binder.Bind(&signup, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(signup, DeepEquals, Signup{Username: "ab", Password: "s3cr3t", Country: "NL"})
+	c.Assert(reported, DeepEquals, Errors{
+		{FieldNames: []string{"Username"}, Classification: MinSizeError, Message: "MinSize"},
+		{FieldNames: []string{"Password"}, Classification: MinSizeError, Message: "MinSize"},
+	})
+
+	//the enforced rules still fail the binding, the shadow rules never set defaults
+	reported = nil
+	signup = Signup{}
+	err = binder.Bind(&signup, newRequest(`POST`, ``, `{"password": "s3cr3t-passw0rd"}`, jsonContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Username"}, Classification: RequiredError, Message: "Required"}})
+	c.Assert(signup.Country, Equals, "")
+	c.Assert(reported, DeepEquals, Errors{
+		{FieldNames: []string{"Username"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Username"}, Classification: MinSizeError, Message: "MinSize"},
+		{FieldNames: []string{"Country"}, Classification: RequiredError, Message: "Required"},
+	})
+}
+
+func (s *shadowSuite) Test_ShadowRulesWithoutCallback(c *C) {
+	err := Bind(&Signup{}, newRequest(`POST`, ``, `{"username": "ab", "password": "s3cr3t"}`, jsonContentType))
+
+	c.Assert(err, IsNil)
+}
@@ -0,0 +1,89 @@
+package binding
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// Stats holds counters and warnings about the binding of a single request.
+// Attach it to the request with WithStats before binding; the binders
+// fill it in. The field counters are only collected by Form and
+// MultipartForm, BytesRead is collected by every binding.
+type Stats struct {
+	// FieldsBound is the number of fields that received a value
+	FieldsBound int
+
+	// FieldsSkipped is the number of fields for which no value was posted
+	FieldsSkipped int
+
+	// UnknownKeys is the number of posted keys that match no field
+	UnknownKeys int
+
+	// FilesReceived is the number of uploaded files
+	FilesReceived int
+
+	// BytesRead is the number of bytes read from the request body
+	BytesRead int64
+
+	// Records is the number of records read by the CSV and NDJSON bindings,
+	// including the records that failed to decode
+	Records int
+
+	// Warnings holds the issues that did not fail the binding, like values
+	// posted under a deprecated name; they are also passed to the OnWarnings
+	// hook of the binder
+	Warnings Errors
+
+	// Aliases maps the key of each field that was supplied through a
+	// deprecated name to that name, for example "headline" to "title"
+	Aliases map[string]string
+}
+
+type statsContextKey struct{}
+
+// WithStats returns a shallow copy of req that collects the binding
+// statistics into stats.
+func WithStats(req *http.Request, stats *Stats) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), statsContextKey{}, stats))
+}
+
+// requestStats returns the stats attached to the request, or a throwaway
+// Stats when the caller did not ask for them.
+func requestStats(req *http.Request) *Stats {
+	if stats, ok := req.Context().Value(statsContextKey{}).(*Stats); ok && stats != nil {
+		return stats
+	}
+	return &Stats{}
+}
+
+// countBody wraps the request body so every byte read is added to stats.
+func countBody(req *http.Request, stats *Stats) {
+	if req.Body != nil {
+		req.Body = &countingReader{ReadCloser: req.Body, count: &stats.BytesRead}
+	}
+}
+
+type countingReader struct {
+	io.ReadCloser
+	count *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	*r.count += int64(n)
+	return n, err
+}
+
+// warn adds warnings to the Stats of req and passes them to the OnWarnings
+// hook of the binder, which is not called by a dry run.
+func (b *Binder) warn(req *http.Request, warnings Errors) {
+	if len(warnings) == 0 {
+		return
+	}
+	stats := requestStats(req)
+	stats.Warnings = append(stats.Warnings, warnings...)
+	if b.OnWarnings != nil && !b.dryRun {
+		b.OnWarnings(req, warnings)
+	}
+}
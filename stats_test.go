@@ -0,0 +1,64 @@
+package binding
+
+import . "gopkg.in/check.v1"
+
+type statsSuite struct{}
+
+var _ = Suite(&statsSuite{})
+
+func (s *statsSuite) Test_Form(c *C) {
+	post := Post{}
+	body := `title=Glorious+Post+Title&unknown=foo`
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, body, formContentType)
+	err := Form.Bind(&post, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+	c.Assert(stats, DeepEquals, &Stats{FieldsBound: 1, FieldsSkipped: 1, UnknownKeys: 1, BytesRead: int64(len(body))})
+}
+
+func (s *statsSuite) Test_MultipartFiles(c *C) {
+	blogPost := BlogPost{}
+	req := buildRequestWithFile([]fileInfo{
+		fileInfo{
+			fieldName: "picture",
+			fileName:  "one.txt",
+			data:      "one",
+		},
+		fileInfo{
+			fieldName: "picture",
+			fileName:  "two.txt",
+			data:      "two",
+		},
+	})
+	size := req.ContentLength
+	stats := &Stats{}
+	err := MultipartForm.Bind(&blogPost, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(stats.FilesReceived, Equals, 2)
+	c.Assert(stats.FieldsBound, Equals, 1)
+	c.Assert(stats.UnknownKeys, Equals, 0)
+	c.Assert(stats.BytesRead, Equals, size)
+}
+
+func (s *statsSuite) Test_Json(c *C) {
+	post := Post{}
+	body := `{"title": "Glorious Post Title"}`
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, body, jsonContentType)
+	err := JSON.Bind(&post, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(stats.BytesRead, Equals, int64(len(body)))
+}
+
+func (s *statsSuite) Test_WithoutStats(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title`, formContentType)
+	err := Form.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+}
@@ -0,0 +1,102 @@
+package binding
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+)
+
+type textBinding struct {
+	binder *Binder
+}
+
+func (_ textBinding) Name() string {
+	return "text"
+}
+
+// Text copies the raw request body into the string or []byte field of the
+// struct marked with an empty name and the body option, `form:",body"`, and
+// validates the struct. Bind uses it for text/plain bodies and for content
+// types it does not recognize, provided the struct has such a field; without
+// one the binding fails with ErrorUnsupportedContentType.
+func (t textBinding) Bind(dst interface{}, req *http.Request) (err error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	binder := binderOrDefault(t.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = binder.done(dst, req, t.Name(), err) }()
+
+	v = v.Elem()
+	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		if binder.NoPointerAllocation {
+			return ErrorInputIsNilPointer
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return ErrorInputIsNotStructure
+	}
+
+	field := rawBodyField(v.Type())
+	if field < 0 {
+		return ErrorUnsupportedContentType
+	}
+
+	if err := binder.checkHeaders(req); err != nil {
+		return err
+	}
+
+	if req.Body != nil {
+		countBody(req, requestStats(req))
+		if err := binder.decodeBody(req); err != nil {
+			return err
+		}
+		defer req.Body.Close()
+
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return readError(err)
+		}
+		if v.Field(field).Kind() == reflect.String {
+			v.Field(field).SetString(string(data))
+		} else {
+			v.Field(field).SetBytes(data)
+		}
+	}
+	return binder.validate(dst, req)
+}
+
+// isRawBodyField reports whether the field receives the raw request body.
+func isRawBodyField(field reflect.StructField) bool {
+	name, options := parseTag(field.Tag.Get("form"))
+	return name == "" && options.Has("body") &&
+		(field.Type.Kind() == reflect.String || field.Type == bytesType)
+}
+
+// rawBodyField returns the index of the field that receives the raw request
+// body, or -1 when the struct has none.
+func rawBodyField(typ reflect.Type) int {
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.PkgPath == "" && isRawBodyField(field) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasRawBodyField reports whether obj points to a struct with a field that
+// receives the raw request body.
+func hasRawBodyField(obj interface{}) bool {
+	typ := reflect.TypeOf(obj)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ != nil && typ.Kind() == reflect.Struct && rawBodyField(typ) >= 0
+}
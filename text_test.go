@@ -0,0 +1,54 @@
+package binding
+
+import . "gopkg.in/check.v1"
+
+type textSuite struct{}
+
+var _ = Suite(&textSuite{})
+
+type Webhook struct {
+	Event   string `form:"event,query" binding:"Required"`
+	Payload string `form:",body" binding:"Required;MaxSize(32)"`
+}
+
+type RawWebhook struct {
+	Payload []byte `form:",body"`
+}
+
+func (s *textSuite) Test_TextPlain(c *C) {
+	webhook := Webhook{}
+	req := newRequest(`POST`, ``, `All your binding are belong to us`, "text/plain; charset=utf-8")
+	err := Bind(&webhook, req)
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Event"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Payload"}, Classification: MaxSizeError, Message: "MaxSize"},
+	})
+	c.Assert(webhook.Payload, Equals, "All your binding are belong to us")
+}
+
+func (s *textSuite) Test_UnrecognizedContentType(c *C) {
+	webhook := RawWebhook{}
+	req := newRequest(`POST`, ``, `<signed payload>`, "application/vnd.provider.event")
+	err := Bind(&webhook, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(webhook.Payload, DeepEquals, []byte(`<signed payload>`))
+}
+
+func (s *textSuite) Test_TextPlainWithoutBodyField(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `Lorem ipsum`, "text/plain")
+	err := Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedContentType)
+}
+
+func (s *textSuite) Test_BodyFieldIgnoredByForm(c *C) {
+	webhook := Webhook{}
+	req := newRequest(`POST`, `?event=push`, `payload=form+value`, formContentType)
+	err := Bind(&webhook, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Payload"}, Classification: RequiredError, Message: "Required"}})
+	c.Assert(webhook.Event, Equals, "push")
+}
@@ -0,0 +1,230 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// tagLocations caches the locations loaded for time_location tags by name.
+var tagLocations sync.Map
+
+// naiveTimeLayouts are the accepted layouts for timestamps without a zone;
+// they are interpreted in the location of the field or binder.
+var naiveTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// location returns the location naive timestamps of the field are interpreted in,
+// from its time_location tag, the binder or UTC. A tag naming a zone that
+// cannot be loaded is a mistake in the struct, so it panics.
+func (b *Binder) location(field reflect.StructField) *time.Location {
+	if name := field.Tag.Get("time_location"); name != "" {
+		return tagLocation(name)
+	}
+
+	if b.TimeLocation != nil {
+		return b.TimeLocation
+	}
+	return time.UTC
+}
+
+// tagLocation loads the location of a time_location tag once.
+func tagLocation(name string) *time.Location {
+	if loc, ok := tagLocations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic("binding: invalid time_location " + name + ": " + err.Error())
+	}
+	tagLocations.Store(name, loc)
+	return loc
+}
+
+// parseUnixTime parses an epoch in seconds ("unix") or milliseconds ("unixmilli").
+func parseUnixTime(format, val string) (time.Time, bool) {
+	epoch, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	switch format {
+	case "unix":
+		return time.Unix(epoch, 0), true
+	case "unixmilli":
+		return time.UnixMilli(epoch), true
+	}
+	return time.Time{}, false
+}
+
+// isUnixTimeFormat reports whether the time_format tag selects epoch values.
+func isUnixTimeFormat(format string) bool {
+	return format == "unix" || format == "unixmilli"
+}
+
+// parseTime parses val with the layout of a time_format tag, or an epoch in
+// seconds or milliseconds for "unix" and "unixmilli". Without a layout it
+// accepts RFC 3339, the naive layouts, interpreted in loc, and an epoch in
+// seconds. An empty value is the zero time.
+func parseTime(format, val string, loc *time.Location) (time.Time, bool) {
+	if val == "" {
+		return time.Time{}, true
+	}
+
+	if isUnixTimeFormat(format) {
+		t, ok := parseUnixTime(format, val)
+		return t.In(loc), ok
+	} else if format != "" {
+		t, err := time.ParseInLocation(format, val, loc)
+		return t, err == nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, val); err == nil {
+		return t, true
+	}
+	for _, layout := range naiveTimeLayouts {
+		if t, err := time.ParseInLocation(layout, val, loc); err == nil {
+			return t, true
+		}
+	}
+	if t, ok := parseUnixTime("unix", val); ok {
+		return t.In(loc), true
+	}
+	return time.Time{}, false
+}
+
+// setTime parses val into a time.Time field. Timestamps with a zone keep it,
+// naive timestamps are interpreted in the location of the field. Fields tagged
+// with time_format take a value in its layout, or an epoch for "unix" and
+// "unixmilli". It reports false when val does not parse. The form bindings and
+// the request parameters share it.
+func (b *Binder) setTime(val string, structField reflect.Value, field reflect.StructField) bool {
+	t, ok := parseTime(field.Tag.Get("time_format"), val, b.location(field))
+	if !ok {
+		return false
+	}
+
+	if b.TimeUTC {
+		t = t.UTC()
+	}
+	structField.Set(reflect.ValueOf(t))
+	return true
+}
+
+// hasUnixTimeFields reports whether typ contains time.Time fields tagged with
+// a unix time_format, which the JSON decoder cannot read by itself.
+func hasUnixTimeFields(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ == timeType || seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Type == timeType && isUnixTimeFormat(field.Tag.Get("time_format")) {
+			return true
+		}
+		if hasUnixTimeFields(field.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertUnixTimes rewrites the epoch numbers of unix formatted time fields in a
+// JSON document into RFC 3339 strings, so the JSON decoder can read them. The
+// document is returned unchanged when it is not valid JSON.
+func convertUnixTimes(data []byte, typ reflect.Type) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return data
+	}
+
+	convertUnixTimeValues(doc, typ)
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return data
+	}
+	return converted
+}
+
+func convertUnixTimeValues(doc interface{}, typ reflect.Type) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch value := doc.(type) {
+	case []interface{}:
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			for _, elem := range value {
+				convertUnixTimeValues(elem, typ.Elem())
+			}
+		}
+	case map[string]interface{}:
+		if typ.Kind() == reflect.Map {
+			for _, elem := range value {
+				convertUnixTimeValues(elem, typ.Elem())
+			}
+		} else if typ.Kind() == reflect.Struct {
+			convertUnixTimeFields(value, typ)
+		}
+	}
+}
+
+func convertUnixTimeFields(object map[string]interface{}, typ reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _ := parseTag(field.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				convertUnixTimeFields(object, fieldType)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		for key, value := range object {
+			if !strings.EqualFold(key, name) {
+				continue
+			}
+
+			format := field.Tag.Get("time_format")
+			if field.Type == timeType && isUnixTimeFormat(format) {
+				if number, ok := value.(json.Number); ok {
+					if t, ok := parseUnixTime(format, number.String()); ok {
+						object[key] = t.Format(time.RFC3339Nano)
+					}
+				}
+			} else {
+				convertUnixTimeValues(value, field.Type)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package binding
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type timeSuite struct{}
+
+var _ = Suite(&timeSuite{})
+
+type Appointment struct {
+	Start time.Time `form:"start"`
+	Local time.Time `form:"local" time_location:"Europe/Amsterdam"`
+}
+
+func (s *timeSuite) Test_ZonedTimestamp(c *C) {
+	appointment := Appointment{}
+	req := newRequest(`GET`, `?start=2015-06-01T10:30:00%2B02:00`, ``, formContentType)
+	err := Form.Bind(&appointment, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(appointment.Start.Equal(time.Date(2015, 6, 1, 8, 30, 0, 0, time.UTC)), Equals, true)
+	_, offset := appointment.Start.Zone()
+	c.Assert(offset, Equals, 2*60*60)
+}
+
+func (s *timeSuite) Test_InvalidTimeLocation(c *C) {
+	appointment := struct {
+		Local time.Time `form:"local" time_location:"Europe/Amsterdm"`
+	}{}
+	req := newRequest(`GET`, `?local=2015-06-01T10:30`, ``, formContentType)
+
+	c.Assert(func() { Form.Bind(&appointment, req) }, PanicMatches, "binding: invalid time_location Europe/Amsterdm: .*")
+}
+
+func (s *timeSuite) Test_NaiveTimestampDefaultsToUTC(c *C) {
+	appointment := Appointment{}
+	req := newRequest(`GET`, `?start=2015-06-01T10:30`, ``, formContentType)
+	err := Form.Bind(&appointment, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(appointment.Start, DeepEquals, time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC))
+}
+
+func (s *timeSuite) Test_NaiveTimestampInBinderLocation(c *C) {
+	appointment := Appointment{}
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	req := newRequest(`GET`, `?start=2015-06-01`, ``, formContentType)
+	binder := &Binder{TimeLocation: loc}
+	err := binder.Form().Bind(&appointment, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(appointment.Start, DeepEquals, time.Date(2015, 6, 1, 0, 0, 0, 0, loc))
+}
+
+func (s *timeSuite) Test_NaiveTimestampInFieldLocation(c *C) {
+	appointment := Appointment{}
+	req := newRequest(`GET`, `?local=2015-06-01T10:30:00`, ``, formContentType)
+	binder := &Binder{TimeUTC: true}
+	err := binder.Form().Bind(&appointment, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(appointment.Local, DeepEquals, time.Date(2015, 6, 1, 8, 30, 0, 0, time.UTC))
+}
+
+func (s *timeSuite) Test_InvalidTimestamp(c *C) {
+	appointment := Appointment{}
+	req := newRequest(`GET`, `?start=yesterday`, ``, formContentType)
+	err := Form.Bind(&appointment, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"start"}, Classification: TimeTypeError, Message: "Invalid time"}})
+	c.Assert(appointment, DeepEquals, Appointment{})
+}
+
+type Event struct {
+	Title     string    `form:"title" json:"title"`
+	Created   time.Time `form:"created" json:"created" time_format:"unix"`
+	Updated   time.Time `form:"updated" json:"updated" time_format:"unixmilli"`
+	Published time.Time `form:"published" json:"published"`
+}
+
+type EventBatch struct {
+	Events []Event `json:"events"`
+	First  *Event  `json:"first"`
+}
+
+func (s *timeSuite) Test_UnixForm(c *C) {
+	event := Event{}
+	req := newRequest(`GET`, `?created=1433154600&updated=1433154600123`, ``, formContentType)
+	err := Form.Bind(&event, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(event.Created, DeepEquals, time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC))
+	c.Assert(event.Updated, DeepEquals, time.Date(2015, 6, 1, 10, 30, 0, 123000000, time.UTC))
+}
+
+func (s *timeSuite) Test_UnixJson(c *C) {
+	event := Event{}
+	req := newRequest(`POST`, ``, `{"title": "Launch", "created": 1433154600, "updated": 1433154600123, "published": "2015-06-01T10:30:00Z"}`, jsonContentType)
+	err := JSON.Bind(&event, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(event.Title, Equals, "Launch")
+	c.Assert(event.Created.Equal(time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC)), Equals, true)
+	c.Assert(event.Updated.Equal(time.Date(2015, 6, 1, 10, 30, 0, 123000000, time.UTC)), Equals, true)
+	c.Assert(event.Published.Equal(time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC)), Equals, true)
+}
+
+func (s *timeSuite) Test_UnixJsonNested(c *C) {
+	batch := EventBatch{}
+	req := newRequest(`POST`, ``, `{"events": [{"created": 1433154600}, {"created": 0}], "first": {"created": 1433154600}}`, jsonContentType)
+	err := JSON.Bind(&batch, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(batch.Events, HasLen, 2)
+	c.Assert(batch.Events[0].Created.Equal(time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC)), Equals, true)
+	c.Assert(batch.Events[1].Created.Equal(time.Unix(0, 0)), Equals, true)
+	c.Assert(batch.First.Created.Equal(time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC)), Equals, true)
+}
+
+func (s *timeSuite) Test_UnixJsonMalformed(c *C) {
+	event := Event{}
+	req := newRequest(`POST`, ``, `{"created": 1433154600`, jsonContentType)
+	err := JSON.Bind(&event, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+type Reservation struct {
+	Day     time.Time `form:"day" time_format:"2006-01-02"`
+	Arrival time.Time `form:"arrival" time_format:"02/01/2006 15:04"`
+	Seen    time.Time `form:"seen"`
+	Since   time.Time `query:"since"`
+}
+
+func (s *timeSuite) Test_TimeFormat(c *C) {
+	reservation := Reservation{}
+	req := newRequest(`POST`, `/reservations?since=1433154600`, `day=2015-06-01&arrival=01/06/2015 14:30&seen=1433154600`, formContentType)
+	err := Bind(&reservation, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(reservation.Day, DeepEquals, time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(reservation.Arrival, DeepEquals, time.Date(2015, 6, 1, 14, 30, 0, 0, time.UTC))
+	c.Assert(reservation.Seen.Equal(time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC)), Equals, true)
+	c.Assert(reservation.Since.Equal(time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC)), Equals, true)
+}
+
+func (s *timeSuite) Test_TimeFormatMismatch(c *C) {
+	err := Form.Bind(&Reservation{}, newRequest(`POST`, ``, `day=2015-06-01T10:30:00Z`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"day"}, Classification: TimeTypeError, Message: "Invalid time"}})
+}
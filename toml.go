@@ -0,0 +1,21 @@
+package binding
+
+import "net/http"
+
+type tomlBinding struct {
+	binder *Binder
+}
+
+func (_ tomlBinding) Name() string {
+	return "toml"
+}
+
+// Toml deserializes a TOML payload from the request into the struct that is
+// passed in and validates it like the JSON binding does. This package does not
+// depend on a TOML library; the binding decodes with the TOMLUnmarshal function
+// of the binder, for example toml.Unmarshal from github.com/BurntSushi/toml,
+// and fails with ErrorUnsupportedContentType when none is set.
+func (t tomlBinding) Bind(dst interface{}, req *http.Request) error {
+	binder := binderOrDefault(t.binder)
+	return binder.unmarshalBody(dst, req, t.Name(), binder.TOMLUnmarshal)
+}
@@ -0,0 +1,58 @@
+package binding
+
+import (
+	"errors"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type tomlSuite struct{}
+
+var _ = Suite(&tomlSuite{})
+
+// unmarshalTOMLName stands in for toml.Unmarshal, it only understands a name key.
+func unmarshalTOMLName(data []byte, v interface{}) error {
+	key, value, found := strings.Cut(strings.TrimSpace(string(data)), "=")
+	if !found || strings.TrimSpace(key) != "name" {
+		return errors.New("expected name key")
+	}
+	v.(*RequiredName).Name = strings.Trim(strings.TrimSpace(value), `"`)
+	return nil
+}
+
+func (s *tomlSuite) Test_TOML(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `name = "Matt Holt"`, "application/toml")
+	binder := &Binder{TOMLUnmarshal: unmarshalTOMLName}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(person, DeepEquals, RequiredName{Name: "Matt Holt"})
+}
+
+func (s *tomlSuite) Test_TOMLValidation(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `name = ""`, "application/toml")
+	binder := &Binder{TOMLUnmarshal: unmarshalTOMLName}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *tomlSuite) Test_TOMLMalformed(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `[name`, "application/toml")
+	binder := &Binder{TOMLUnmarshal: unmarshalTOMLName}
+	err := binder.Bind(&person, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *tomlSuite) Test_TOMLWithoutUnmarshal(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `name = "Matt Holt"`, "application/toml")
+	err := Bind(&person, req)
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedContentType)
+}
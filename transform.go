@@ -0,0 +1,114 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Transform rewrites a bound string value before it is validated.
+type Transform func(value string) string
+
+var (
+	transformsMutex sync.RWMutex
+	transforms      = map[string]Transform{
+		"trim":            strings.TrimSpace,
+		"lower":           strings.ToLower,
+		"upper":           strings.ToUpper,
+		"title_case":      titleCase,
+		"collapse_spaces": collapseSpaces,
+	}
+)
+
+// RegisterTransform registers a transform under name for the transform tag,
+// like `transform:"trim,title_case,collapse_spaces"`. The transforms of a
+// field run in the order of the tag on its string values, after binding and
+// before the binding rules, in Validate as well. A tag naming a transform that
+// is not registered panics the first time it is used. Registering a name again
+// replaces the transform, including the built in trim, lower, upper,
+// title_case and collapse_spaces.
+func RegisterTransform(name string, transform Transform) {
+	transformsMutex.Lock()
+	defer transformsMutex.Unlock()
+	transforms[name] = transform
+}
+
+// transformFunc returns the transform registered under name.
+func transformFunc(name string) (Transform, bool) {
+	transformsMutex.RLock()
+	defer transformsMutex.RUnlock()
+	transform, ok := transforms[name]
+	return transform, ok
+}
+
+// applyTransforms runs the comma separated transforms on the string, *string
+// or []string field. A transform that is not registered is a mistake in the
+// struct tag, not in the request, so it panics before the field is touched.
+func applyTransforms(field reflect.Value, names string) {
+	chain := []Transform{}
+	for _, name := range strings.Split(names, ",") {
+		transform, ok := transformFunc(strings.TrimSpace(name))
+		if !ok {
+			panic("binding: unknown transform " + strings.TrimSpace(name))
+		}
+		chain = append(chain, transform)
+	}
+
+	apply := func(value reflect.Value) {
+		str := value.String()
+		for _, transform := range chain {
+			str = transform(str)
+		}
+		value.SetString(str)
+	}
+
+	switch {
+	case field.Kind() == reflect.String:
+		apply(field)
+	case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.String && !field.IsNil():
+		apply(field.Elem())
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < field.Len(); i++ {
+			apply(field.Index(i))
+		}
+	}
+}
+
+// titleCase upper cases the first letter of every word and lower cases the rest.
+func titleCase(value string) string {
+	var title strings.Builder
+	start := true
+	for _, r := range value {
+		if unicode.IsSpace(r) {
+			start = true
+			title.WriteRune(r)
+			continue
+		}
+		if start {
+			title.WriteRune(unicode.ToUpper(r))
+		} else {
+			title.WriteRune(unicode.ToLower(r))
+		}
+		start = false
+	}
+	return title.String()
+}
+
+// collapseSpaces replaces every run of white space with a single space.
+func collapseSpaces(value string) string {
+	var collapsed strings.Builder
+	space := false
+	for _, r := range value {
+		if unicode.IsSpace(r) {
+			if !space {
+				collapsed.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		collapsed.WriteRune(r)
+		space = false
+	}
+	return collapsed.String()
+}
@@ -0,0 +1,95 @@
+package binding
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// byteUnits are the suffixes of byte sizes, decimal and binary.
+var byteUnits = []struct {
+	suffix string
+	size   float64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30}, {"T", 1 << 40},
+	{"B", 1},
+}
+
+// unitValues converts the values of a field tagged with unit into the plain
+// numbers strconv parses: `unit:"percent"` turns 75% into 0.75,
+// `unit:"bytes"` turns 10MB into 10000000 and 1KiB into 1024, and
+// `unit:"duration"` turns 2h into its nanoseconds, as time.Duration fields do
+// without the tag.
+// Values without a unit are returned as is; it reports false for a value that
+// does not parse.
+func unitValues(values []string, unit string) ([]string, bool) {
+	converted := make([]string, len(values))
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		var ok bool
+		switch unit {
+		case "percent":
+			converted[i], ok = percentValue(value)
+		case "bytes":
+			converted[i], ok = byteSizeValue(value)
+		case "duration":
+			converted[i], ok = durationUnitValue(value)
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	return converted, true
+}
+
+func percentValue(value string) (string, bool) {
+	if !strings.HasSuffix(value, "%") {
+		_, err := strconv.ParseFloat(value, 64)
+		return value, err == nil
+	}
+
+	percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatFloat(percent/100, 'g', -1, 64), true
+}
+
+func byteSizeValue(value string) (string, bool) {
+	if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return value, true
+	}
+
+	for _, unit := range byteUnits {
+		if !strings.HasSuffix(strings.ToUpper(value), strings.ToUpper(unit.suffix)) {
+			continue
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(value[:len(value)-len(unit.suffix)]), 64)
+		if err != nil || number < 0 {
+			return "", false
+		}
+		return strconv.FormatFloat(number*unit.size, 'f', 0, 64), true
+	}
+	return "", false
+}
+
+func durationUnitValue(value string) (string, bool) {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value, true
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatInt(int64(duration), 10), true
+}
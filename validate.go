@@ -0,0 +1,611 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	alphaDashPattern    = regexp.MustCompile("[^\\d\\w-_]")
+	alphaDashDotPattern = regexp.MustCompile("[^\\d\\w-_\\.]")
+	emailPattern        = regexp.MustCompile("[\\w!#$%&'*+/=?^_`{|}~-]+(?:\\.[\\w!#$%&'*+/=?^_`{|}~-]+)*@(?:[\\w](?:[\\w-]*[\\w])?\\.)+[a-zA-Z0-9](?:[\\w-]*[\\w])?")
+	decimalPattern      = regexp.MustCompile(`^[-+]?(\d*)(?:\.(\d*))?$`)
+	urlPattern          = regexp.MustCompile(`(http|https):\/\/[\w\-_]+(\.[\w\-_]+)+([\w\-\.,@?^=%&amp;:/~\+#]*[\w\-\@?^=%&amp;/~\+#])?`)
+)
+
+// Validate runs the rules declared in the `binding` struct tags of obj
+// and returns the collected Errors, or nil when every rule passes.
+// Like a binding, it first runs the transforms of the `transform` tags and
+// fills in the Default rules, so the fields of obj are changed in place.
+func Validate(obj interface{}) error {
+	return validateWith(obj, validation{})
+}
+
+// validation holds the options of a validation run.
+type validation struct {
+	// workers is the number of goroutines validating large slices of structs
+	workers int
+
+	// provenance records the rule and field that produced each error
+	provenance bool
+
+	// wireNames names the fields in the errors by their form or json name
+	wireNames bool
+
+	// onRule is called for every rule that is evaluated
+	onRule func(usage RuleUsage)
+
+	// requiredFiles reports missing required files as a RequiredFileError on
+	// their form key
+	requiredFiles bool
+
+	// clock returns the current time, nil uses time.Now
+	clock func() time.Time
+
+	// rand is the source of generated identifiers, nil uses crypto/rand
+	rand io.Reader
+
+	// ctx is passed to the custom rules, nil uses the background context
+	ctx context.Context
+
+	// warn receives the violations of the soft rules, nil drops them
+	warn func(warnings Errors)
+
+	// shadow evaluates the rules of the shadow tag instead of the binding tag,
+	// without running transforms or setting defaults
+	shadow bool
+}
+
+// ruleTag returns the name of the struct tag holding the rules.
+func (o validation) ruleTag() string {
+	if o.shadow {
+		return "shadow"
+	}
+	return "binding"
+}
+
+// RuleUsage describes the evaluation of a single rule, as passed to the
+// OnRule callback of a binder.
+type RuleUsage struct {
+	// Model is the struct type declaring the field
+	Model reflect.Type
+
+	// Field is the name of the struct field
+	Field string
+
+	// Rule is the rule as written in the binding tag, like "MinSize(3)"
+	Rule string
+
+	// Failed reports whether the value did not satisfy the rule
+	Failed bool
+}
+
+// fieldName returns the name of the field used in the error field names.
+func (o validation) fieldName(field reflect.StructField) string {
+	if o.wireNames {
+		for _, tag := range []string{"form", "json"} {
+			if name, _ := parseTag(field.Tag.Get(tag)); name != "" && name != "-" {
+				return name
+			}
+		}
+	}
+	return field.Name
+}
+
+// report passes the evaluation of rule on field of model to the OnRule
+// callback.
+func (o validation) report(model reflect.Type, field reflect.StructField, rule string, failed bool) {
+	if o.onRule != nil && rule != "" {
+		o.onRule(RuleUsage{Model: model, Field: field.Name, Rule: rule, Failed: failed})
+	}
+}
+
+// ruleError returns the error of rule failing on field of model, named name,
+// and reports the failure to the OnRule callback. A field can replace the
+// classification of its errors with the errclass tag, and the errors of soft
+// rules are warnings.
+func (o validation) ruleError(model reflect.Type, field reflect.StructField, name, rule, classification, message string, soft bool) Error {
+	if errorClass := field.Tag.Get("errclass"); errorClass != "" {
+		classification = errorClass
+	}
+	err := NewFieldError(name, classification, message)
+	if soft {
+		err.Severity = SeverityWarning
+	}
+	if o.provenance {
+		err.Source = "rule:" + rule + " " + model.String() + "." + field.Name
+	}
+	o.report(model, field, rule, true)
+	return err
+}
+
+// validationOptions returns the options of validating the request with the
+// binder, which passes ctx to the custom rules and reports the violations of
+// soft rules as warnings of req.
+func (b *Binder) validationOptions(req *http.Request, ctx context.Context) validation {
+	return validation{
+		workers:       b.ValidationWorkers,
+		provenance:    b.Provenance,
+		wireNames:     b.WireFieldNames,
+		onRule:        b.OnRule,
+		requiredFiles: b.RequiredFileErrors,
+		clock:         b.Clock,
+		rand:          b.Random,
+		ctx:           ctx,
+		warn: func(warnings Errors) {
+			if req != nil {
+				b.warn(req, warnings)
+			}
+		},
+	}
+}
+
+// validate runs the validation rules on dst and, when they pass, the Unique
+// and Exists rules against the DBValidator of the binder, and then checks the
+// fields tagged with the Timestamp and Nonce rules.
+func (b *Binder) validate(dst interface{}, req *http.Request) error {
+	if b.skipValidation {
+		return nil
+	}
+	ctx := b.ruleContext(req)
+	defer b.validateShadow(dst, req, ctx)
+	options := b.validationOptions(req, ctx)
+	if err := validateWith(dst, options); err != nil {
+		return err
+	}
+	if err := b.checkStore(ctx, dst, options); err != nil {
+		return err
+	}
+	return b.checkReplay(dst, options)
+}
+
+// validateWith validates obj like Validate, with the given options.
+func validateWith(obj interface{}, options validation) error {
+	var warnings Errors
+	errors := validateStruct(nil, reflect.ValueOf(obj), "", options)
+	for i := 0; i < len(errors); i++ {
+		if errors[i].Severity == SeverityWarning {
+			warnings = append(warnings, errors[i])
+			errors = append(errors[:i], errors[i+1:]...)
+			i--
+		}
+	}
+	if len(warnings) > 0 && options.warn != nil {
+		options.warn(warnings)
+	}
+	if errors.Len() > 0 {
+		return errors
+	}
+	return nil
+}
+
+// Performs required field checking on a struct
+func validateStruct(errors Errors, val reflect.Value, path string, options validation) Errors {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return errors
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return errors
+	}
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Allow ignored fields in the struct
+		if field.Tag.Get("form") == "-" || !val.Field(i).CanInterface() || isUnbindable(field.Type) {
+			continue
+		}
+
+		fieldVal := val.Field(i)
+		fieldValue := fieldVal.Interface()
+		zero := reflect.Zero(field.Type).Interface()
+
+		rule, passing, soft := "", "", false
+		report := func(rule string, failed bool) {
+			options.report(typ, field, rule, failed)
+		}
+		errorName := options.fieldName(field)
+		if options.requiredFiles && isFileField(field.Type) {
+			errorName = formFieldName(field)
+		}
+		addError := func(classification, message string) {
+			errors = append(errors, options.ruleError(typ, field, path+errorName, rule, classification, message, soft))
+			passing = ""
+		}
+
+		// Run the transforms of the field before its rules
+		if names := field.Tag.Get("transform"); names != "" && fieldVal.CanSet() && !options.shadow {
+			applyTransforms(fieldVal, names)
+			fieldValue = fieldVal.Interface()
+		}
+
+		// Validate nested and embedded structs (if pointer, only do so if not nil)
+		if field.Type.Kind() == reflect.Struct ||
+			(field.Type.Kind() == reflect.Ptr && !reflect.DeepEqual(zero, fieldValue) &&
+				field.Type.Elem().Kind() == reflect.Struct) {
+			fieldPath := path
+			if field.Anonymous == false {
+				fieldPath = path + options.fieldName(field) + "."
+			}
+			errors = validateStruct(errors, fieldVal, fieldPath, options)
+			// Validate structure slices
+		} else if field.Type.Kind() == reflect.Slice &&
+			(field.Type.Elem().Kind() == reflect.Struct ||
+				(field.Type.Elem().Kind() == reflect.Ptr && field.Type.Elem().Elem().Kind() == reflect.Struct)) {
+			errors = validateSlice(errors, fieldVal, path+options.fieldName(field)+".", options)
+			// Validate raw JSON against its registered model
+		} else if name := field.Tag.Get("raw_model"); name != "" && field.Type == rawMessageType {
+			errors = validateRawModel(errors, name, fieldVal.Bytes(), path+options.fieldName(field), options)
+		}
+
+		// The rules check the value a pointer field points to. A nil pointer was
+		// not sent, so only Required, Default and the custom rules apply to it
+		ruleValue, nilPointer := indirectValue(fieldVal)
+
+		// Match rules, the soft rules marked with a leading ? after the others
+		for _, soft = range []bool{false, true} {
+		VALIDATE_RULES:
+			for _, rule = range strings.Split(field.Tag.Get(options.ruleTag()), ";") {
+				if len(rule) == 0 || strings.HasPrefix(rule, "?") != soft {
+					continue
+				}
+				rule = strings.TrimPrefix(rule, "?")
+				if isStoreRule(rule) {
+					continue
+				}
+
+				if nilPointer && !appliesToNil(rule) {
+					continue
+				}
+
+				//the previous rule passed when it did not add an error
+				report(passing, false)
+				passing = rule
+
+				switch {
+				case rule == "Required":
+					if reflect.DeepEqual(zero, fieldValue) && options.requiredFiles && isFileField(field.Type) {
+						addError(RequiredFileError, "Required file")
+						break
+					} else if reflect.DeepEqual(zero, fieldValue) {
+						addError(RequiredError, "Required")
+						break
+					}
+				case rule == "AlphaDash":
+					if alphaDashPattern.MatchString(fmt.Sprintf("%v", ruleValue)) {
+						addError(AlphaDashError, "AlphaDash")
+						break VALIDATE_RULES
+					}
+				case rule == "AlphaDashDot":
+					if alphaDashDotPattern.MatchString(fmt.Sprintf("%v", ruleValue)) {
+						addError(AlphaDashDotError, "AlphaDashDot")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "MinSize("):
+					min, _ := strconv.Atoi(rule[8 : len(rule)-1])
+					if size, ok := valueSize(fieldVal); ok && size < min {
+						addError(MinSizeError, "MinSize")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "MaxSize("):
+					max, _ := strconv.Atoi(rule[8 : len(rule)-1])
+					if size, ok := valueSize(fieldVal); ok && size > max {
+						addError(MaxSizeError, "MaxSize")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "Length("):
+					length, _ := strconv.Atoi(rule[7 : len(rule)-1])
+					if size, ok := valueSize(fieldVal); ok && size != length {
+						addError(LengthError, "Length")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "MinDuration("):
+					min, err := time.ParseDuration(rule[12 : len(rule)-1])
+					if duration, ok := durationValue(fieldVal); ok && err == nil && duration < min {
+						addError(MinDurationError, "MinDuration")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "MaxDuration("):
+					max, err := time.ParseDuration(rule[12 : len(rule)-1])
+					if duration, ok := durationValue(fieldVal); ok && err == nil && duration > max {
+						addError(MaxDurationError, "MaxDuration")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "Decimal("):
+					precision, scale := decimalArgs(rule)
+					str, ok := decimalValue(fieldVal)
+					if !ok || len(str) == 0 {
+						passing = ""
+						continue
+					}
+					if !isDecimal(str, precision, scale) {
+						addError(DecimalError, "Decimal")
+						break VALIDATE_RULES
+					}
+				case rule == "Email":
+					if !emailPattern.MatchString(fmt.Sprintf("%v", ruleValue)) {
+						addError(EmailError, "Email")
+						break VALIDATE_RULES
+					}
+				case rule == "Url":
+					str := fmt.Sprintf("%v", ruleValue)
+					if len(str) == 0 {
+						passing = ""
+						continue
+					} else if !urlPattern.MatchString(str) {
+						addError(UrlError, "Url")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "Range("):
+					nums := strings.Split(rule[6:len(rule)-1], ",")
+					if len(nums) != 2 {
+						break
+					}
+					val, _ := strconv.ParseInt(fmt.Sprintf("%v", ruleValue), 10, 32)
+					a, _ := strconv.ParseInt(nums[0], 10, 32)
+					b, _ := strconv.ParseInt(nums[1], 10, 32)
+					if val < a || val > b {
+						addError(RangeError, "Range")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "In("):
+					if !in(ruleValue, rule[3:len(rule)-1]) {
+						addError(InError, "In")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "NotIn("):
+					if in(ruleValue, rule[6:len(rule)-1]) {
+						addError(NotInError, "NotIn")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "Include("):
+					if !strings.Contains(fmt.Sprintf("%v", ruleValue), rule[8:len(rule)-1]) {
+						addError(IncludeError, "Include")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "Exclude("):
+					if strings.Contains(fmt.Sprintf("%v", ruleValue), rule[8:len(rule)-1]) {
+						addError(ExcludeError, "Exclude")
+						break VALIDATE_RULES
+					}
+				case rule == "Latitude":
+					if !isCoordinate(fieldVal, 90) {
+						addError(LatitudeError, "Latitude")
+						break VALIDATE_RULES
+					}
+				case rule == "Longitude":
+					if !isCoordinate(fieldVal, 180) {
+						addError(LongitudeError, "Longitude")
+						break VALIDATE_RULES
+					}
+				case rule == "Color":
+					if str := fmt.Sprintf("%v", ruleValue); str != "" {
+						if _, err := ParseColor(str); err != nil {
+							addError(ColorError, "Color")
+							break VALIDATE_RULES
+						}
+					}
+				case rule == "Locale":
+					if str := fmt.Sprintf("%v", ruleValue); str != "" && !isLocale(str) {
+						addError(LocaleError, "Locale")
+						break VALIDATE_RULES
+					}
+				case rule == "Timezone":
+					if str := fmt.Sprintf("%v", ruleValue); str != "" && !isTimezone(str) {
+						addError(TimezoneError, "Timezone")
+						break VALIDATE_RULES
+					}
+				case rule == "IP":
+					if str := fmt.Sprintf("%v", ruleValue); str != "" && net.ParseIP(str) == nil {
+						addError(IPError, "IP")
+						break VALIDATE_RULES
+					}
+				case rule == "CIDR":
+					if str := fmt.Sprintf("%v", ruleValue); str != "" {
+						if _, _, err := net.ParseCIDR(str); err != nil {
+							addError(CIDRError, "CIDR")
+							break VALIDATE_RULES
+						}
+					}
+				case strings.HasPrefix(rule, "Before("):
+					t, ok := timeValue(fieldVal)
+					if before, valid := options.ruleTime(rule[7 : len(rule)-1]); ok && valid && !t.Before(before) {
+						addError(BeforeError, "Before")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "After("):
+					t, ok := timeValue(fieldVal)
+					if after, valid := options.ruleTime(rule[6 : len(rule)-1]); ok && valid && !t.After(after) {
+						addError(AfterError, "After")
+						break VALIDATE_RULES
+					}
+				case strings.HasPrefix(rule, "Default("):
+					if reflect.DeepEqual(zero, fieldValue) && !options.shadow {
+						if fieldVal.CanSet() {
+							if !options.setDefault(rule[8:len(rule)-1], fieldVal) {
+								setWithProperType(field.Type.Kind(), rule[8:len(rule)-1], fieldVal, field.Tag.Get("form"))
+							}
+						} else {
+							addError(DefaultError, "Default")
+							break VALIDATE_RULES
+						}
+					}
+				default:
+					if name, custom, arg, ok := lookupRule(rule); ok && !custom.check(options.context(), fieldValue, arg) {
+						addError(custom.classification, name)
+						break VALIDATE_RULES
+					}
+				}
+			}
+			report(passing, false)
+			passing = ""
+		}
+	}
+	return errors
+}
+
+// parallelValidationThreshold is the length from which a slice of structs is
+// validated by multiple goroutines.
+const parallelValidationThreshold = 256
+
+// validateSlice validates the structs in a slice. Large slices are spread over
+// the workers of the options; the errors are merged in the order of the elements.
+func validateSlice(errors Errors, slice reflect.Value, path string, options validation) Errors {
+	if options.workers < 2 || slice.Len() < parallelValidationThreshold {
+		for i := 0; i < slice.Len(); i++ {
+			errors = validateStruct(errors, slice.Index(i), path+strconv.Itoa(i)+".", options)
+		}
+		return errors
+	}
+
+	results := make([]Errors, slice.Len())
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	elementOptions := options
+	elementOptions.workers = 0
+	for w := 0; w < options.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = validateStruct(nil, slice.Index(i), path+strconv.Itoa(i)+".", elementOptions)
+			}
+		}()
+	}
+	for i := range results {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, result := range results {
+		errors = append(errors, result...)
+	}
+	return errors
+}
+
+// valueSize returns the size of strings (in runes), slices, arrays and maps,
+// following pointers, and of uploaded files (in bytes). The boolean result is false when the value has no size,
+// for example a nil pointer or a numeric field.
+func valueSize(v reflect.Value) (int, bool) {
+	if v.Type() == fileType && !v.IsNil() {
+		return int(v.Interface().(*File).Size), true
+	}
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// isDecimal reports whether str is a decimal number that fits the SQL style
+// DECIMAL(precision, scale): at most scale digits after the decimal point and
+// at most precision-scale digits before it.
+func isDecimal(str string, precision, scale int) bool {
+	parts := decimalPattern.FindStringSubmatch(str)
+	if parts == nil || len(parts[1])+len(parts[2]) == 0 {
+		return false
+	}
+
+	integer := strings.TrimLeft(parts[1], "0")
+	return len(parts[2]) <= scale && len(integer) <= precision-scale
+}
+
+// indirectValue returns the value of a field for the rules, following
+// pointers. The boolean result is true for a nil pointer.
+func indirectValue(v reflect.Value) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), false
+}
+
+// appliesToNil reports whether a rule is checked for a nil pointer field.
+func appliesToNil(rule string) bool {
+	if rule == "Required" || strings.HasPrefix(rule, "Default(") {
+		return true
+	}
+	_, _, _, custom := lookupRule(rule)
+	return custom
+}
+
+// decimalArgs returns the precision and scale of a Decimal(precision,scale)
+// rule. A malformed rule is a mistake in the struct tag and panics.
+func decimalArgs(rule string) (int, int) {
+	nums := strings.Split(rule[8:len(rule)-1], ",")
+	if len(nums) == 2 {
+		precision, precisionErr := strconv.Atoi(strings.TrimSpace(nums[0]))
+		scale, scaleErr := strconv.Atoi(strings.TrimSpace(nums[1]))
+		if precisionErr == nil && scaleErr == nil && precision > 0 && scale >= 0 && scale <= precision {
+			return precision, scale
+		}
+	}
+	panic("binding: malformed rule " + rule + ", want Decimal(precision,scale)")
+}
+
+// decimalValue returns the value of a field checked by the Decimal rule as
+// text, following pointers. The boolean result is false for a nil pointer.
+func decimalValue(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprintf("%v", v.Interface()), true
+}
+
+// durationValue returns the value of a time.Duration field, following pointers.
+func durationValue(v reflect.Value) (time.Duration, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+
+	duration, ok := v.Interface().(time.Duration)
+	return duration, ok
+}
+
+// validation in function
+func in(fieldValue interface{}, arr string) bool {
+	val := fmt.Sprintf("%v", fieldValue)
+	vals := strings.Split(arr, ",")
+	isIn := false
+	for _, v := range vals {
+		if v == val {
+			isIn = true
+			break
+		}
+	}
+	return isIn
+}
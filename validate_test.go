@@ -0,0 +1,417 @@
+package binding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type validateSuite struct{}
+
+var _ = Suite(&validateSuite{})
+
+type Sizes struct {
+	Tags     map[string]string `binding:"MinSize(1);MaxSize(2)"`
+	Codes    [2]int            `binding:"MaxSize(2)"`
+	Names    *[]string         `binding:"MinSize(2)"`
+	Country  string            `binding:"Length(2)"`
+	Keywords []string          `binding:"Length(2)"`
+}
+
+type ArraySize struct {
+	Codes [3]int `binding:"MaxSize(2)"`
+}
+
+func (s *validateSuite) Test_SizeRulesHappyPath(c *C) {
+	names := []string{"foo", "bar"}
+	sizes := Sizes{
+		Tags:     map[string]string{"a": "b"},
+		Names:    &names,
+		Country:  "nl",
+		Keywords: []string{"a", "b"},
+	}
+	err := Validate(&sizes)
+
+	c.Assert(err, IsNil)
+}
+
+func (s *validateSuite) Test_SizeRulesOnMapsAndPointers(c *C) {
+	names := []string{"foo"}
+	sizes := Sizes{
+		Tags:     map[string]string{"a": "b", "c": "d", "e": "f"},
+		Names:    &names,
+		Country:  "nld",
+		Keywords: []string{"a"},
+	}
+	err := Validate(sizes)
+
+	c.Assert(err, NotNil)
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Tags"}, Classification: MaxSizeError, Message: "MaxSize"},
+		{FieldNames: []string{"Names"}, Classification: MinSizeError, Message: "MinSize"},
+		{FieldNames: []string{"Country"}, Classification: LengthError, Message: "Length"},
+		{FieldNames: []string{"Keywords"}, Classification: LengthError, Message: "Length"},
+	})
+}
+
+func (s *validateSuite) Test_SizeRulesOnArrays(c *C) {
+	err := Validate(&ArraySize{})
+
+	c.Assert(err, NotNil)
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Codes"}, Classification: MaxSizeError, Message: "MaxSize"}})
+}
+
+func (s *validateSuite) Test_SizeRulesSkipNilPointer(c *C) {
+	sizes := Sizes{
+		Tags:     map[string]string{"a": "b"},
+		Country:  "nl",
+		Keywords: []string{"a", "b"},
+	}
+	err := Validate(&sizes)
+
+	c.Assert(err, IsNil)
+}
+
+func (s *validateSuite) Test_NilPointer(c *C) {
+	err := Validate((*Sizes)(nil))
+
+	c.Assert(err, IsNil)
+}
+
+type ErrorClassOverride struct {
+	Title   string `binding:"Required" errclass:"missing_title"`
+	Content string `binding:"Required"`
+}
+
+func (s *validateSuite) Test_ErrorClassOverride(c *C) {
+	err := Validate(&ErrorClassOverride{})
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Title"}, Classification: "missing_title", Message: "Required"},
+		{FieldNames: []string{"Content"}, Classification: RequiredError, Message: "Required"},
+	})
+}
+
+type Timeouts struct {
+	Timeout time.Duration  `binding:"MinDuration(1s);MaxDuration(24h)"`
+	TTL     *time.Duration `binding:"MaxDuration(1m30s)"`
+}
+
+func (s *validateSuite) Test_DurationRules(c *C) {
+	ttl := 90 * time.Second
+	err := Validate(&Timeouts{Timeout: time.Second, TTL: &ttl})
+
+	c.Assert(err, IsNil)
+}
+
+func (s *validateSuite) Test_DurationRulesViolated(c *C) {
+	err := Validate(&Timeouts{Timeout: 500 * time.Millisecond})
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Timeout"}, Classification: MinDurationError, Message: "MinDuration"}})
+
+	err = Validate(&Timeouts{Timeout: 25 * time.Hour})
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Timeout"}, Classification: MaxDurationError, Message: "MaxDuration"}})
+
+	ttl := 2 * time.Minute
+	err = Validate(&Timeouts{Timeout: time.Minute, TTL: &ttl})
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"TTL"}, Classification: MaxDurationError, Message: "MaxDuration"}})
+}
+
+type Amount struct {
+	Price string `binding:"Decimal(5,2)"`
+}
+
+func (s *validateSuite) Test_DecimalRule(c *C) {
+	for _, price := range []string{"", "0", "123.45", "-123.4", "+0.5", "00012.30", ".5", "999."} {
+		c.Assert(Validate(&Amount{Price: price}), IsNil, Commentf("%q", price))
+	}
+
+	for _, price := range []string{"1234.5", "12.345", "1e5", "abc", "1.2.3", "-", "."} {
+		c.Assert(Validate(&Amount{Price: price}), DeepEquals, Errors{{FieldNames: []string{"Price"}, Classification: DecimalError, Message: "Decimal"}}, Commentf("%q", price))
+	}
+}
+
+type OptionalAmount struct {
+	Price *string `form:"price" binding:"Decimal(10,2)"`
+}
+
+func (s *validateSuite) Test_DecimalRuleOnPointer(c *C) {
+	price := "12.50"
+	c.Assert(Validate(&OptionalAmount{Price: &price}), IsNil)
+	c.Assert(Validate(&OptionalAmount{}), IsNil)
+
+	price = "12.505"
+	c.Assert(Validate(&OptionalAmount{Price: &price}), DeepEquals, Errors{{FieldNames: []string{"Price"}, Classification: DecimalError, Message: "Decimal"}})
+}
+
+type PatchContact struct {
+	Email    *string `form:"email" binding:"Email"`
+	Handle   *string `form:"handle" binding:"AlphaDash;Include(_)"`
+	Role     *string `form:"role" binding:"In(admin,editor)"`
+	Priority *int    `form:"priority" binding:"Range(1,5)"`
+	Website  *string `form:"website" binding:"Url"`
+}
+
+func (s *validateSuite) Test_RulesOnPointerFields(c *C) {
+	contact := PatchContact{}
+	body := `email=a@b.com&handle=glorious_handle&role=editor&priority=3&website=https://example.com`
+	err := Form.Bind(&contact, newRequest(`POST`, ``, body, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(*contact.Email, Equals, "a@b.com")
+
+	//fields that were not sent are not checked
+	c.Assert(Form.Bind(&PatchContact{}, newRequest(`POST`, ``, `role=admin`, formContentType)), IsNil)
+
+	body = `email=not-an-email&handle=glorious.handle&role=owner&priority=9`
+	err = Form.Bind(&PatchContact{}, newRequest(`POST`, ``, body, formContentType))
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Email"}, Classification: EmailError, Message: "Email"},
+		{FieldNames: []string{"Handle"}, Classification: AlphaDashError, Message: "AlphaDash"},
+		{FieldNames: []string{"Role"}, Classification: InError, Message: "In"},
+		{FieldNames: []string{"Priority"}, Classification: RangeError, Message: "Range"},
+	})
+}
+
+type MalformedDecimal struct {
+	Price string `binding:"Decimal(10)"`
+}
+
+type NonNumericDecimal struct {
+	Price string `binding:"Decimal(ten,two)"`
+}
+
+func (s *validateSuite) Test_DecimalRuleMalformed(c *C) {
+	c.Assert(func() { Validate(&MalformedDecimal{Price: "1.5"}) }, PanicMatches, `binding: malformed rule Decimal\(10\).*`)
+	c.Assert(func() { Validate(&NonNumericDecimal{}) }, PanicMatches, `binding: malformed rule Decimal\(ten,two\).*`)
+}
+
+type Import struct {
+	Rows []ImportRow
+}
+
+type ImportRow struct {
+	Name  string `binding:"Required"`
+	Email string `binding:"Email"`
+}
+
+func (s *validateSuite) Test_ParallelSliceValidation(c *C) {
+	rows := make([]ImportRow, 1000)
+	for i := range rows {
+		rows[i] = ImportRow{Name: "row", Email: "row@example.com"}
+		if i%7 == 0 {
+			rows[i].Name = ""
+		}
+		if i%11 == 0 {
+			rows[i].Email = "invalid"
+		}
+	}
+
+	serial := validateWith(&Import{Rows: rows}, validation{})
+	parallel := validateWith(&Import{Rows: rows}, validation{workers: 8})
+
+	c.Assert(serial, NotNil)
+	c.Assert(parallel, DeepEquals, serial)
+	c.Assert(serial.(Errors)[0].FieldNames, DeepEquals, []string{"Rows.0.Name"})
+}
+
+func (s *validateSuite) Test_ProvenanceOfEmbeddedRules(c *C) {
+	err := validateWith(&struct{ Sizes }{}, validation{provenance: true})
+
+	c.Assert(err.(Errors)[0].Source, Equals, "rule:MinSize(1) binding.Sizes.Tags")
+}
+
+func (s *validateSuite) Test_OnRule(c *C) {
+	usages := []RuleUsage{}
+	binder := &Binder{OnRule: func(usage RuleUsage) { usages = append(usages, usage) }}
+	err := binder.validate(&Sizes{Tags: map[string]string{"a": "b"}, Country: "nld", Keywords: []string{"a", "b"}}, nil)
+
+	typ := reflect.TypeOf(Sizes{})
+	c.Assert(err, NotNil)
+	c.Assert(usages, DeepEquals, []RuleUsage{
+		{Model: typ, Field: "Tags", Rule: "MinSize(1)"},
+		{Model: typ, Field: "Tags", Rule: "MaxSize(2)"},
+		{Model: typ, Field: "Codes", Rule: "MaxSize(2)"},
+		{Model: typ, Field: "Country", Rule: "Length(2)", Failed: true},
+		{Model: typ, Field: "Keywords", Rule: "Length(2)"},
+	})
+}
+
+type Bookmark struct {
+	Link  string  `json:"link" binding:"Url"`
+	Price string  `json:"price" binding:"Decimal(6,2)"`
+	Title *string `json:"title" binding:"MinSize(2)"`
+}
+
+func (s *validateSuite) Test_OnRuleSkipsUnevaluatedRules(c *C) {
+	usages := []RuleUsage{}
+	binder := &Binder{OnRule: func(usage RuleUsage) { usages = append(usages, usage) }}
+	c.Assert(binder.validate(&Bookmark{}, nil), IsNil)
+	c.Assert(usages, HasLen, 0)
+
+	title := "Go"
+	c.Assert(binder.validate(&Bookmark{Link: "http://example.com", Price: "1.50", Title: &title}, nil), IsNil)
+	c.Assert(usages, HasLen, 3)
+}
+
+type WidgetConfig struct {
+	Color string `json:"color" binding:"Required;In(red,green)"`
+	Size  int    `json:"size" binding:"Range(1,10)"`
+}
+
+type Widget struct {
+	Kind   string          `json:"kind"`
+	Config json.RawMessage `json:"config" raw_model:"WidgetConfig"`
+}
+
+func (s *validateSuite) Test_RawModel(c *C) {
+	RegisterRawModel("WidgetConfig", WidgetConfig{})
+
+	c.Assert(Validate(&Widget{Config: json.RawMessage(`{"color": "red", "size": 3}`)}), IsNil)
+	c.Assert(Validate(&Widget{}), IsNil)
+	c.Assert(Validate(&Widget{Config: json.RawMessage(`{"color": "blue", "size": 3}`)}), DeepEquals, Errors{
+		{FieldNames: []string{"Config.Color"}, Classification: InError, Message: "In"},
+	})
+	c.Assert(Validate(&Widget{Config: json.RawMessage(`{"color": 1}`)}), DeepEquals, Errors{
+		{FieldNames: []string{"Config"}, Classification: DeserializationError, Message: "Malformed JSON"},
+	})
+}
+
+func (s *validateSuite) Test_UnknownRawModel(c *C) {
+	model := struct {
+		Config json.RawMessage `raw_model:"UnknownConfig"`
+	}{Config: json.RawMessage(`{}`)}
+
+	c.Assert(Validate(&model), DeepEquals, Errors{
+		{FieldNames: []string{"Config"}, Classification: DeserializationError, Message: "Unknown model UnknownConfig"},
+	})
+}
+
+type Booking struct {
+	Id        string     `binding:"Default(uuid)"`
+	CreatedAt time.Time  `binding:"Default(now)"`
+	Arrival   time.Time  `binding:"After(now)"`
+	Departure *time.Time `binding:"After(now);Before(2030-01-01)"`
+}
+
+func (s *validateSuite) Test_Clock(c *C) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	binder := &Binder{Clock: func() time.Time { return now }, Random: bytes.NewReader(make([]byte, 16))}
+	departure := now.AddDate(0, 0, 7)
+	booking := Booking{Arrival: now.AddDate(0, 0, 1), Departure: &departure}
+	err := binder.validate(&booking, nil)
+
+	c.Assert(err, IsNil)
+	c.Assert(booking.Id, Equals, "00000000-0000-4000-8000-000000000000")
+	c.Assert(booking.CreatedAt, Equals, now)
+
+	departure = time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = binder.validate(&Booking{Arrival: now, Departure: &departure}, nil)
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Arrival"}, Classification: AfterError, Message: "After"},
+		{FieldNames: []string{"Departure"}, Classification: BeforeError, Message: "Before"},
+	})
+}
+
+type ContactCard struct {
+	Name     string   `form:"name" transform:"trim,collapse_spaces,title_case" binding:"Required"`
+	Nickname string   `form:"nickname" transform:"trim,lower"`
+	Labels   []string `form:"label" transform:"slug"`
+}
+
+func (s *validateSuite) Test_Transform(c *C) {
+	RegisterTransform("slug", func(value string) string {
+		return strings.Replace(strings.ToLower(strings.TrimSpace(value)), " ", "-", -1)
+	})
+	contact := ContactCard{}
+	req := newRequest(`POST`, ``, `name=+jOHN+++de%09vries+&nickname=+JD+&label=Best+Friend&label=Work`, formContentType)
+	err := Form.Bind(&contact, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(contact, DeepEquals, ContactCard{Name: "John De Vries", Nickname: "jd", Labels: []string{"best-friend", "work"}})
+
+	err = Form.Bind(&ContactCard{}, newRequest(`POST`, ``, `name=+++`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *validateSuite) Test_UnknownTransform(c *C) {
+	model := struct {
+		Name string `transform:"trim,shout"`
+	}{Name: " John "}
+
+	c.Assert(func() { Validate(&model) }, PanicMatches, "binding: unknown transform shout")
+	c.Assert(model.Name, Equals, " John ")
+}
+
+type planKey struct{}
+
+type Album struct {
+	Photos []string `json:"photos" binding:"MaxSizeByPlan(photos)"`
+}
+
+func (s *validateSuite) Test_CustomRuleWithContextValues(c *C) {
+	limits := map[string]map[string]int{"free": {"photos": 2}, "pro": {"photos": 100}}
+	RegisterRule("MaxSizeByPlan", "PlanLimitError", func(ctx context.Context, value interface{}, arg string) bool {
+		plan, _ := ctx.Value(planKey{}).(string)
+		return len(value.([]string)) <= limits[plan][arg]
+	})
+	binder := &Binder{ContextValues: map[interface{}]ValueProvider{
+		planKey{}: func(req *http.Request) interface{} { return req.Header.Get("X-Plan") },
+	}}
+	body := `{"photos": ["a.jpg", "b.jpg", "c.jpg"]}`
+
+	req := newRequest(`POST`, ``, body, jsonContentType)
+	req.Header.Set("X-Plan", "pro")
+	c.Assert(binder.Bind(&Album{}, req), IsNil)
+
+	req = newRequest(`POST`, ``, body, jsonContentType)
+	req.Header.Set("X-Plan", "free")
+	err := binder.Bind(&Album{}, req)
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Photos"}, Classification: "PlanLimitError", Message: "MaxSizeByPlan"}})
+
+	//values set on the request context by a middleware reach the rule as well
+	req = newRequest(`POST`, ``, body, jsonContentType)
+	req = req.WithContext(context.WithValue(req.Context(), planKey{}, "pro"))
+	c.Assert(Bind(&Album{}, req), IsNil)
+}
+
+type Review struct {
+	Title string `json:"title" binding:"Required;?MinSize(10)"`
+	Body  string `json:"body" binding:"?Required;MaxSize(20)"`
+}
+
+func (s *validateSuite) Test_SoftRules(c *C) {
+	stats := &Stats{}
+	req := newRequest(`POST`, ``, `{"title": "Short"}`, jsonContentType)
+	err := Bind(&Review{}, WithStats(req, stats))
+
+	c.Assert(err, IsNil)
+	c.Assert(stats.Warnings, DeepEquals, Errors{
+		{FieldNames: []string{"Title"}, Classification: MinSizeError, Message: "MinSize", Severity: SeverityWarning},
+		{FieldNames: []string{"Body"}, Classification: RequiredError, Message: "Required", Severity: SeverityWarning},
+	})
+
+	//the other rules are still enforced, next to the soft ones
+	stats = &Stats{}
+	req = newRequest(`POST`, ``, `{"body": "A glorious but far too long body"}`, jsonContentType)
+	err = Bind(&Review{}, WithStats(req, stats))
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Title"}, Classification: RequiredError, Message: "Required"},
+		{FieldNames: []string{"Body"}, Classification: MaxSizeError, Message: "MaxSize"},
+	})
+	c.Assert(stats.Warnings, DeepEquals, Errors{
+		{FieldNames: []string{"Title"}, Classification: MinSizeError, Message: "MinSize", Severity: SeverityWarning},
+	})
+
+	//without a request the warnings are dropped
+	c.Assert(Validate(&Review{Title: "Glorious Review Title", Body: ""}), IsNil)
+}
@@ -0,0 +1,126 @@
+package binding
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// BindValues binds the values into obj like the Form binding, using the
+// default binder, see Binder.BindValues.
+func BindValues(obj interface{}, values url.Values) Errors {
+	return defaultBinder.BindValues(obj, values)
+}
+
+// BindValues maps the values into the struct obj points to, using the form
+// tags like the Form binding, and validates it. It needs no http.Request, so
+// the same structs can be bound from command line flags or the messages of a
+// queue. Failures that are not validation errors, like an exceeded limit, are
+// returned as a single classified entry.
+func (b *Binder) BindValues(obj interface{}, values url.Values) Errors {
+	return toErrors(b.bindValues(obj, values))
+}
+
+func (b *Binder) bindValues(obj interface{}, values url.Values) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr {
+		return ErrorInputNotByReference
+	}
+
+	v = v.Elem()
+	if v.Kind() == reflect.Ptr && v.CanSet() && v.IsNil() {
+		if b.NoPointerAllocation {
+			return ErrorInputIsNilPointer
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanSet() {
+		return ErrorInputIsNotStructure
+	}
+
+	if err := b.Limits.checkForm(values, nil); err != nil {
+		return err
+	}
+	mapper := newFormMapper(b, values, nil)
+	if err := mapper.mapStruct(v); err != nil {
+		return err
+	}
+	return b.validate(obj, nil)
+}
+
+// BindReader decodes the body read from r into obj using the default binder,
+// see Binder.BindReader.
+func BindReader(obj interface{}, r io.Reader, contentType string) Errors {
+	return defaultBinder.BindReader(obj, r, contentType)
+}
+
+// BindReader decodes the body read from r into obj with the binding Bind
+// selects for contentType, and validates it, so messages from a queue or
+// files go through the same decoding as request bodies. Failures that are not
+// validation errors are returned as a single classified entry.
+func (b *Binder) BindReader(obj interface{}, r io.Reader, contentType string) Errors {
+	return toErrors(b.bindBody(obj, payloadRequest(r, -1, contentType)))
+}
+
+// BindBytes decodes the payload into obj using the default binder, see
+// Binder.BindBytes.
+func BindBytes(obj interface{}, data []byte, contentType string) Errors {
+	return defaultBinder.BindBytes(obj, data, contentType)
+}
+
+// BindBytes decodes a payload, like the value of a message from Kafka or
+// NATS, into obj with the binding Bind selects for contentType, and validates
+// it. The length of the payload is known, so the Content-Length checks and
+// limits of the binder apply as they do to requests.
+func (b *Binder) BindBytes(obj interface{}, data []byte, contentType string) Errors {
+	return toErrors(b.bindBody(obj, payloadRequest(bytes.NewReader(data), int64(len(data)), contentType)))
+}
+
+// payloadRequest returns the bare request the bindings decode a payload from
+// that did not arrive over HTTP.
+func payloadRequest(r io.Reader, length int64, contentType string) *http.Request {
+	body := io.NopCloser(r)
+	if length == 0 {
+		body = http.NoBody
+	}
+	return &http.Request{
+		Method:        "POST",
+		URL:           &url.URL{},
+		Header:        http.Header{"Content-Type": {contentType}},
+		Body:          body,
+		ContentLength: length,
+	}
+}
+
+// BindResponse decodes the body of an API response into obj using the default
+// binder, see Binder.BindResponse.
+func BindResponse(obj interface{}, resp *http.Response) Errors {
+	return defaultBinder.BindResponse(obj, resp)
+}
+
+// BindResponse decodes the body of resp into obj with the binding Bind
+// selects for the Content-Type of the response, and validates it, so clients
+// get the same Errors as servers. The body is closed. Compressed bodies and
+// the Content-Length checks of the binder are handled like for requests.
+func (b *Binder) BindResponse(obj interface{}, resp *http.Response) Errors {
+	if resp == nil {
+		return toErrors(ErrorNilResponse)
+	}
+
+	req := payloadRequest(resp.Body, resp.ContentLength, resp.Header.Get("Content-Type"))
+	if resp.Body == nil {
+		req.Body = nil
+	} else {
+		defer resp.Body.Close()
+	}
+	for _, header := range []string{"Content-Encoding", "Content-Length", "Digest", "Content-MD5"} {
+		if values, exists := resp.Header[header]; exists {
+			req.Header[header] = values
+		}
+	}
+	return toErrors(b.bindBody(obj, req))
+}
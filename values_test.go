@@ -0,0 +1,120 @@
+package binding
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type valuesSuite struct{}
+
+var _ = Suite(&valuesSuite{})
+
+type ImportCommand struct {
+	Source  string   `form:"source" binding:"Required"`
+	Limit   int      `form:"limit"`
+	Tags    []string `form:"tag"`
+	Options struct {
+		DryRun bool `form:"dry_run"`
+	} `form:"options"`
+}
+
+func (s *valuesSuite) Test_BindValues(c *C) {
+	command := ImportCommand{}
+	errs := BindValues(&command, url.Values{"source": {"feed.csv"}, "limit": {"10"}, "tag[]": {"a", "b"}, "options.dry_run": {"true"}})
+
+	c.Assert(errs, HasLen, 0)
+	c.Assert(command.Source, Equals, "feed.csv")
+	c.Assert(command.Limit, Equals, 10)
+	c.Assert(command.Tags, DeepEquals, []string{"a", "b"})
+	c.Assert(command.Options.DryRun, Equals, true)
+}
+
+func (s *valuesSuite) Test_BindValuesValidates(c *C) {
+	errs := BindValues(&ImportCommand{}, url.Values{"limit": {"10"}})
+
+	c.Assert(errs, DeepEquals, Errors{{FieldNames: []string{"Source"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *valuesSuite) Test_BindValuesLimits(c *C) {
+	binder := &Binder{Limits: Limits{MaxKeys: 1}}
+	errs := binder.BindValues(&ImportCommand{}, url.Values{"source": {"feed.csv"}, "limit": {"10"}})
+
+	c.Assert(errs, DeepEquals, Errors{{Classification: LimitExceededError, Message: "MaxKeys exceeded"}})
+}
+
+func (s *valuesSuite) Test_BindValuesNotByReference(c *C) {
+	errs := BindValues(ImportCommand{}, url.Values{})
+
+	c.Assert(errs, DeepEquals, toErrors(ErrorInputNotByReference))
+}
+
+func (s *valuesSuite) Test_BindReader(c *C) {
+	command := ImportCommand{}
+	errs := BindReader(&command, strings.NewReader(`source=feed.csv&tag=a&tag=b`), formContentType)
+
+	c.Assert(errs, HasLen, 0)
+	c.Assert(command.Source, Equals, "feed.csv")
+	c.Assert(command.Tags, DeepEquals, []string{"a", "b"})
+
+	post := Post{}
+	errs = BindReader(&post, io.MultiReader(strings.NewReader(`{"title": `), strings.NewReader(`"Glorious Post Title"}`)), MIMEJSON)
+
+	c.Assert(errs, HasLen, 0)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+}
+
+func (s *valuesSuite) Test_BindReaderErrors(c *C) {
+	errs := BindReader(&ImportCommand{}, strings.NewReader(`limit=1`), formContentType)
+	c.Assert(errs, DeepEquals, Errors{{FieldNames: []string{"Source"}, Classification: RequiredError, Message: "Required"}})
+
+	errs = BindReader(&Post{}, strings.NewReader(`{"title": `), MIMEJSON)
+	c.Assert(errs, DeepEquals, toErrors(ErrorDeserialization))
+
+	errs = BindReader(&Post{}, strings.NewReader(`title`), "application/x-unknown")
+	c.Assert(errs, DeepEquals, toErrors(ErrorUnsupportedContentType))
+}
+
+func (s *valuesSuite) Test_BindBytes(c *C) {
+	post := Post{}
+	errs := BindBytes(&post, []byte(`{"title": "Glorious Post Title"}`), MIMEJSON)
+
+	c.Assert(errs, HasLen, 0)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title"})
+
+	binder := &Binder{RequireContentLength: true, Limits: Limits{MaxValueLength: 5}}
+	errs = binder.BindBytes(&Post{}, []byte(`{"title": "Glorious Post Title"}`), MIMEJSON)
+	c.Assert(errs, DeepEquals, toErrors(limitExceeded("MaxValueLength")))
+}
+
+func (s *valuesSuite) Test_BindResponse(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", jsonContentType)
+		if req.URL.Path == "/invalid" {
+			w.Write([]byte(`{"limit": 10}`))
+			return
+		}
+		w.Write([]byte(`{"Source": "feed.csv", "Limit": 10}`))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	c.Assert(err, IsNil)
+	command := ImportCommand{}
+	errs := BindResponse(&command, resp)
+
+	c.Assert(errs, HasLen, 0)
+	c.Assert(command.Source, Equals, "feed.csv")
+	c.Assert(command.Limit, Equals, 10)
+
+	resp, err = http.Get(server.URL + "/invalid")
+	c.Assert(err, IsNil)
+	errs = BindResponse(&ImportCommand{}, resp)
+
+	c.Assert(errs, DeepEquals, Errors{{FieldNames: []string{"Source"}, Classification: RequiredError, Message: "Required"}})
+	c.Assert(BindResponse(&ImportCommand{}, nil), DeepEquals, toErrors(ErrorNilResponse))
+}
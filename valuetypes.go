@@ -0,0 +1,147 @@
+package binding
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errUnknownTimezone = errors.New("unknown time zone")
+	errInvalidIP       = errors.New("invalid IP address")
+
+	locationType  = reflect.TypeOf((*time.Location)(nil))
+	ipType        = reflect.TypeOf(net.IP{})
+	ipNetType     = reflect.TypeOf(net.IPNet{})
+	ipNetPtrType  = reflect.TypeOf((*net.IPNet)(nil))
+	localePattern = regexp.MustCompile(`^(?i:[a-z]{2,3}(-[a-z]{4})?(-([a-z]{2}|[0-9]{3}))?(-([a-z0-9]{5,8}|[0-9][a-z0-9]{3}))*)$`)
+)
+
+var valueTypesMutex sync.RWMutex
+
+// valueType parses the form and request parameter values of a field holding a
+// value object; a value that does not parse is reported with its
+// classification and message.
+type valueType struct {
+	parse          func(value string) (interface{}, error)
+	classification string
+	message        string
+}
+
+// valueTypes are the value objects bound from a single value: a Color from a
+// hex or rgb color, a *time.Location from an IANA time zone name, a net.IP
+// from an IPv4 or IPv6 address, a net.IPNet from a network in CIDR notation,
+// like 10.0.0.0/8, and the locale type registered with RegisterLocale.
+var valueTypes = map[reflect.Type]valueType{
+	colorType: {
+		parse: func(value string) (interface{}, error) {
+			return ParseColor(value)
+		},
+		classification: ColorError,
+		message:        "Invalid color",
+	},
+	locationType: {
+		parse: func(value string) (interface{}, error) {
+			return loadTimezone(value)
+		},
+		classification: TimezoneError,
+		message:        "Invalid timezone",
+	},
+	ipType: {
+		parse: func(value string) (interface{}, error) {
+			return parseIP(value)
+		},
+		classification: IPError,
+		message:        "Invalid IP address",
+	},
+	ipNetType: {
+		parse: func(value string) (interface{}, error) {
+			network, err := parseCIDR(value)
+			if err != nil {
+				return nil, err
+			}
+			return *network, nil
+		},
+		classification: CIDRError,
+		message:        "Invalid CIDR",
+	},
+	ipNetPtrType: {
+		parse: func(value string) (interface{}, error) {
+			return parseCIDR(value)
+		},
+		classification: CIDRError,
+		message:        "Invalid CIDR",
+	},
+}
+
+// RegisterLocale makes the fields of the type of tag bind from a BCP 47
+// language tag, like en-US, converted by parse. The package does not depend on
+// a language package itself; for language.Tag from golang.org/x/text/language:
+//
+//	binding.RegisterLocale(language.Tag{}, func(value string) (interface{}, error) {
+//		return language.Parse(value)
+//	})
+//
+// A value that does not parse fails with a LocaleError. Registering a type
+// again replaces its parser.
+func RegisterLocale(tag interface{}, parse func(value string) (interface{}, error)) {
+	valueTypesMutex.Lock()
+	defer valueTypesMutex.Unlock()
+	valueTypes[reflect.TypeOf(tag)] = valueType{parse: parse, classification: LocaleError, message: "Invalid locale"}
+}
+
+// lookupValueType returns the value type of the fields of type typ.
+func lookupValueType(typ reflect.Type) (valueType, bool) {
+	valueTypesMutex.RLock()
+	defer valueTypesMutex.RUnlock()
+	value, ok := valueTypes[typ]
+	return value, ok
+}
+
+// parseIP parses an IPv4 or IPv6 address.
+func parseIP(value string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		return nil, errInvalidIP
+	}
+	return ip, nil
+}
+
+// parseCIDR parses a network in CIDR notation, like 10.0.0.0/8.
+func parseCIDR(value string) (*net.IPNet, error) {
+	_, network, err := net.ParseCIDR(strings.TrimSpace(value))
+	return network, err
+}
+
+// loadTimezone loads the location of an IANA time zone name. The empty name
+// and Local are refused, they are UTC and the zone of the server.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, errUnknownTimezone
+	}
+	return time.LoadLocation(name)
+}
+
+// isLocale reports whether value is a BCP 47 language tag of a language,
+// an optional script and region, and variants, like en, en-US or zh-Hant-TW.
+func isLocale(value string) bool {
+	return localePattern.MatchString(value)
+}
+
+// isTimezone reports whether value is an IANA time zone name.
+func isTimezone(value string) bool {
+	_, err := loadTimezone(value)
+	return err == nil
+}
+
+// sliceElem returns the element type of a slice type, or nil for other types.
+func sliceElem(typ reflect.Type) reflect.Type {
+	if typ.Kind() != reflect.Slice {
+		return nil
+	}
+	return typ.Elem()
+}
@@ -0,0 +1,150 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type valueTypesSuite struct{}
+
+var _ = Suite(&valueTypesSuite{})
+
+type UserPreferences struct {
+	Accent    Color          `json:"accent" form:"accent"`
+	Highlight string         `json:"highlight" form:"highlight" binding:"Color"`
+	Locale    string         `json:"locale" form:"locale" binding:"Locale"`
+	Timezone  string         `json:"timezone" form:"timezone" binding:"Timezone"`
+	Zone      *time.Location `json:"-" form:"zone" query:"zone"`
+}
+
+func (s *valueTypesSuite) Test_Form(c *C) {
+	preferences := UserPreferences{}
+	body := `accent=%23F80&highlight=rgba(0,0,255,0.5)&locale=zh-Hant-TW&timezone=America/New_York&zone=Europe/Amsterdam`
+	err := Form.Bind(&preferences, newRequest(`POST`, ``, body, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(preferences.Accent, Equals, Color{R: 255, G: 136, B: 0, A: 255})
+	c.Assert(preferences.Zone.String(), Equals, "Europe/Amsterdam")
+}
+
+func (s *valueTypesSuite) Test_Query(c *C) {
+	preferences := UserPreferences{}
+	err := Query(&preferences, newRequest(`GET`, `/preferences?zone=Asia/Tokyo`, ``, ``))
+
+	c.Assert(err, IsNil)
+	c.Assert(preferences.Zone.String(), Equals, "Asia/Tokyo")
+}
+
+func (s *valueTypesSuite) Test_Malformed(c *C) {
+	err := Form.Bind(&UserPreferences{}, newRequest(`POST`, ``, `accent=orange`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"accent"}, Classification: ColorError, Message: "Invalid color"}})
+
+	err = Form.Bind(&UserPreferences{}, newRequest(`POST`, ``, `zone=Local`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"zone"}, Classification: TimezoneError, Message: "Invalid timezone"}})
+}
+
+func (s *valueTypesSuite) Test_Rules(c *C) {
+	body := `{"highlight": "#12345", "locale": "en_US", "timezone": "Mars/Olympus_Mons"}`
+	err := JSON.Bind(&UserPreferences{}, newRequest(`POST`, ``, body, jsonContentType))
+
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Highlight"}, Classification: ColorError, Message: "Color"},
+		{FieldNames: []string{"Locale"}, Classification: LocaleError, Message: "Locale"},
+		{FieldNames: []string{"Timezone"}, Classification: TimezoneError, Message: "Timezone"},
+	})
+}
+
+func (s *valueTypesSuite) Test_ColorJSON(c *C) {
+	preferences := UserPreferences{}
+	err := JSON.Bind(&preferences, newRequest(`POST`, ``, `{"accent": "rgb(18, 52, 86)"}`, jsonContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(preferences.Accent, Equals, Color{R: 18, G: 52, B: 86, A: 255})
+
+	data, _ := json.Marshal(Color{R: 18, G: 52, B: 86, A: 128})
+	c.Assert(string(data), Equals, `"#12345680"`)
+}
+
+type FirewallRule struct {
+	Source    net.IP      `form:"source" query:"source"`
+	Network   *net.IPNet  `form:"network"`
+	Allowed   []net.IPNet `form:"allow" query:"allow"`
+	Gateway   string      `form:"gateway" binding:"IP"`
+	Exclusion string      `form:"exclude" binding:"CIDR"`
+}
+
+func (s *valueTypesSuite) Test_IPForm(c *C) {
+	rule := FirewallRule{}
+	body := `source=2001:db8::1&network=10.1.2.3/8&allow=192.168.0.0/16&allow=fd00::/8&gateway=10.0.0.1&exclude=10.9.0.0/16`
+	err := Form.Bind(&rule, newRequest(`POST`, ``, body, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(rule.Source.String(), Equals, "2001:db8::1")
+	c.Assert(rule.Network.String(), Equals, "10.0.0.0/8")
+	c.Assert(rule.Allowed, HasLen, 2)
+	c.Assert(rule.Allowed[0].String(), Equals, "192.168.0.0/16")
+	c.Assert(rule.Allowed[1].Contains(net.ParseIP("fd00::42")), Equals, true)
+}
+
+func (s *valueTypesSuite) Test_IPQuery(c *C) {
+	rule := FirewallRule{}
+	err := Query(&rule, newRequest(`GET`, `/rules?source=10.0.0.7&allow=10.0.0.0/24`, ``, ``))
+
+	c.Assert(err, IsNil)
+	c.Assert(rule.Source.String(), Equals, "10.0.0.7")
+	c.Assert(rule.Allowed[0].String(), Equals, "10.0.0.0/24")
+}
+
+func (s *valueTypesSuite) Test_IPMalformed(c *C) {
+	err := Form.Bind(&FirewallRule{}, newRequest(`POST`, ``, `source=10.0.0.256`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"source"}, Classification: IPError, Message: "Invalid IP address"}})
+
+	err = Form.Bind(&FirewallRule{}, newRequest(`POST`, ``, `allow=10.0.0.0/8&allow=10.0.0.0`, formContentType))
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"allow"}, Classification: CIDRError, Message: "Invalid CIDR"}})
+
+	err = Form.Bind(&FirewallRule{}, newRequest(`POST`, ``, `gateway=gateway.local&exclude=10.0.0.0/33`, formContentType))
+	c.Assert(err, DeepEquals, Errors{
+		{FieldNames: []string{"Gateway"}, Classification: IPError, Message: "IP"},
+		{FieldNames: []string{"Exclusion"}, Classification: CIDRError, Message: "CIDR"},
+	})
+}
+
+// languageTag stands in for language.Tag of golang.org/x/text/language.
+type languageTag struct {
+	language, region string
+}
+
+func parseLanguageTag(value string) (interface{}, error) {
+	if !isLocale(value) {
+		return nil, errors.New("invalid language tag")
+	}
+	language, region, _ := strings.Cut(strings.ToLower(value), "-")
+	return languageTag{language: language, region: strings.ToUpper(region)}, nil
+}
+
+type LanguagePreferences struct {
+	Language  languageTag   `form:"language"`
+	Fallbacks []languageTag `form:"fallback"`
+}
+
+func (s *valueTypesSuite) Test_RegisteredLocale(c *C) {
+	RegisterLocale(languageTag{}, parseLanguageTag)
+
+	preferences := LanguagePreferences{}
+	err := Form.Bind(&preferences, newRequest(`POST`, ``, `language=en-us&fallback=nl&fallback=de-AT`, formContentType))
+
+	c.Assert(err, IsNil)
+	c.Assert(preferences, DeepEquals, LanguagePreferences{
+		Language:  languageTag{language: "en", region: "US"},
+		Fallbacks: []languageTag{{language: "nl"}, {language: "de", region: "AT"}},
+	})
+
+	err = Form.Bind(&LanguagePreferences{}, newRequest(`POST`, ``, `language=english+please`, formContentType))
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"language"}, Classification: LocaleError, Message: "Invalid locale"}})
+}
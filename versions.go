@@ -0,0 +1,113 @@
+package binding
+
+import (
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// VersionSelector returns the model version requested by a request,
+// or an empty string when the request does not name a version.
+type VersionSelector func(*http.Request) string
+
+// HeaderVersion selects the version from a request header.
+func HeaderVersion(name string) VersionSelector {
+	return func(req *http.Request) string {
+		return req.Header.Get(name)
+	}
+}
+
+// QueryVersion selects the version from a query string parameter.
+func QueryVersion(param string) VersionSelector {
+	return func(req *http.Request) string {
+		return req.URL.Query().Get(param)
+	}
+}
+
+// MediaTypeVersion selects the version from the Content-Type, either from its
+// version parameter (application/json; version=2) or from a vendor subtype
+// segment (application/vnd.company.v2+json).
+func MediaTypeVersion() VersionSelector {
+	return func(req *http.Request) string {
+		mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if err != nil {
+			return ""
+		}
+
+		if version, ok := params["version"]; ok {
+			return version
+		}
+
+		subtype := mediaType[strings.Index(mediaType, "/")+1:]
+		if i := strings.Index(subtype, "+"); i >= 0 {
+			subtype = subtype[:i]
+		}
+		for _, segment := range strings.Split(subtype, ".") {
+			if len(segment) > 1 && segment[0] == 'v' && strings.Trim(segment[1:], "0123456789") == "" {
+				return segment[1:]
+			}
+		}
+		return ""
+	}
+}
+
+// Versions binds requests into one of several registered versions of a model.
+type Versions struct {
+	// Selector picks the version requested by a request; nil always uses
+	// the Default version
+	Selector VersionSelector
+
+	// Default is the version used when the request does not name one
+	Default string
+
+	// Binder binds the selected model; nil uses the default binder
+	Binder *Binder
+
+	models map[string]reflect.Type
+}
+
+// NewVersions returns an empty set of model versions selected by selector.
+func NewVersions(selector VersionSelector) *Versions {
+	return &Versions{
+		Selector: selector,
+		models:   make(map[string]reflect.Type),
+	}
+}
+
+// Register adds a version of the model. model is a struct value, or a
+// pointer to one, of the type to bind that version into.
+func (v *Versions) Register(version string, model interface{}) *Versions {
+	typ := reflect.TypeOf(model)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if v.models == nil {
+		v.models = make(map[string]reflect.Type)
+	}
+	v.models[version] = typ
+	return v
+}
+
+// BindVersioned binds the request into a new value of the model version it
+// selects and runs the rules of that model. It returns a pointer to the bound
+// model and its version, or ErrorUnsupportedVersion when no such version is
+// registered.
+func (v *Versions) BindVersioned(req *http.Request) (interface{}, string, error) {
+	var version string
+	if v.Selector != nil {
+		version = v.Selector(req)
+	}
+	if version == "" {
+		version = v.Default
+	}
+
+	typ, ok := v.models[version]
+	if !ok {
+		return nil, version, ErrorUnsupportedVersion
+	}
+
+	model := reflect.New(typ).Interface()
+	err := binderOrDefault(v.Binder).Bind(model, req)
+	return model, version, err
+}
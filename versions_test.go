@@ -0,0 +1,76 @@
+package binding
+
+import . "gopkg.in/check.v1"
+
+type versionsSuite struct{}
+
+var _ = Suite(&versionsSuite{})
+
+type PostV2 struct {
+	Headline string `json:"headline" form:"headline" binding:"Required"`
+}
+
+func (s *versionsSuite) Test_HeaderVersion(c *C) {
+	versions := NewVersions(HeaderVersion("Api-Version")).
+		Register("1", Post{}).
+		Register("2", &PostV2{})
+	req := newRequest(`POST`, ``, `{"headline": "Glorious Post Title"}`, jsonContentType)
+	req.Header.Set("Api-Version", "2")
+	model, version, err := versions.BindVersioned(req)
+
+	c.Assert(err, IsNil)
+	c.Assert(version, Equals, "2")
+	c.Assert(model, DeepEquals, &PostV2{Headline: "Glorious Post Title"})
+}
+
+func (s *versionsSuite) Test_DefaultVersion(c *C) {
+	versions := NewVersions(QueryVersion("version")).
+		Register("1", Post{}).
+		Register("2", PostV2{})
+	versions.Default = "1"
+	req := newRequest(`POST`, ``, `title=Glorious+Post+Title`, formContentType)
+	model, version, err := versions.BindVersioned(req)
+
+	c.Assert(err, IsNil)
+	c.Assert(version, Equals, "1")
+	c.Assert(model, DeepEquals, &Post{Title: "Glorious Post Title"})
+}
+
+func (s *versionsSuite) Test_VersionRulesApplied(c *C) {
+	versions := NewVersions(QueryVersion("version")).
+		Register("1", Post{}).
+		Register("2", PostV2{})
+	req := newRequest(`POST`, `?version=2`, `title=Glorious+Post+Title`, formContentType)
+	_, _, err := versions.BindVersioned(req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Headline"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *versionsSuite) Test_UnsupportedVersion(c *C) {
+	versions := NewVersions(QueryVersion("version")).Register("1", Post{})
+	req := newRequest(`POST`, `?version=3`, `title=Glorious+Post+Title`, formContentType)
+	model, version, err := versions.BindVersioned(req)
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedVersion)
+	c.Assert(version, Equals, "3")
+	c.Assert(model, IsNil)
+}
+
+func (s *versionsSuite) Test_MediaTypeVersion(c *C) {
+	selector := MediaTypeVersion()
+
+	c.Assert(selector(newRequest(`POST`, ``, ``, `application/json; version=2`)), Equals, "2")
+	c.Assert(selector(newRequest(`POST`, ``, ``, `application/vnd.company.v3+json`)), Equals, "3")
+	c.Assert(selector(newRequest(`POST`, ``, ``, `application/json`)), Equals, "")
+	c.Assert(selector(newRequest(`POST`, ``, ``, ``)), Equals, "")
+}
+
+func (s *versionsSuite) Test_ZeroValueVersions(c *C) {
+	versions := (&Versions{Default: "2"}).Register("2", PostV2{})
+	req := newRequest(`POST`, `?version=1`, `{"headline": "Glorious Post Title"}`, jsonContentType)
+	model, version, err := versions.BindVersioned(req)
+
+	c.Assert(err, IsNil)
+	c.Assert(version, Equals, "2")
+	c.Assert(model, DeepEquals, &PostV2{Headline: "Glorious Post Title"})
+}
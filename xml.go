@@ -7,24 +7,42 @@ import (
 	"reflect"
 )
 
-type xmlBinding struct{}
+type xmlBinding struct {
+	binder *Binder
+}
 
 func (_ xmlBinding) Name() string {
 	return "xml"
 }
 
-func (_ xmlBinding) Bind(dst interface{}, req *http.Request) error {
+func (x xmlBinding) Bind(dst interface{}, req *http.Request) (err error) {
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr {
 		return ErrorInputNotByReference
 	}
 
+	binder := binderOrDefault(x.binder)
+	if req == nil {
+		return ErrorNilRequest
+	}
+	defer func() { err = binder.done(dst, req, x.Name(), err) }()
+	if binder.NoPointerAllocation && isNilPointerReference(v) {
+		return ErrorInputIsNilPointer
+	}
+
+	if err := binder.checkHeaders(req); err != nil {
+		return err
+	}
 	if req.Body != nil {
+		countBody(req, requestStats(req))
+		if err := binder.decodeBody(req); err != nil {
+			return err
+		}
 		defer req.Body.Close()
 		err := xml.NewDecoder(req.Body).Decode(dst)
 		if err != nil && err != io.EOF {
-			return ErrorDeserialization
+			return readError(err)
 		}
 	}
-	return nil
+	return binder.validate(dst, req)
 }
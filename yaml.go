@@ -0,0 +1,21 @@
+package binding
+
+import "net/http"
+
+type yamlBinding struct {
+	binder *Binder
+}
+
+func (_ yamlBinding) Name() string {
+	return "yaml"
+}
+
+// Yaml deserializes a YAML payload from the request into the struct that is
+// passed in and validates it like the JSON binding does. This package does not
+// depend on a YAML library; the binding decodes with the YAMLUnmarshal function
+// of the binder, for example yaml.Unmarshal from gopkg.in/yaml.v3, and fails
+// with ErrorUnsupportedContentType when none is set.
+func (y yamlBinding) Bind(dst interface{}, req *http.Request) error {
+	binder := binderOrDefault(y.binder)
+	return binder.unmarshalBody(dst, req, y.Name(), binder.YAMLUnmarshal)
+}
@@ -0,0 +1,47 @@
+package binding
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type yamlSuite struct{}
+
+var _ = Suite(&yamlSuite{})
+
+// A JSON document is valid YAML, which lets the tests stand in for a YAML library.
+var yamlBinder = &Binder{YAMLUnmarshal: json.Unmarshal}
+
+func (s *yamlSuite) Test_Yaml(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title": "Glorious Post Title", "content": "Lorem ipsum dolor sit amet"}`, "application/x-yaml")
+	err := yamlBinder.Bind(&post, req)
+
+	c.Assert(err, IsNil)
+	c.Assert(post, DeepEquals, Post{Title: "Glorious Post Title", Content: "Lorem ipsum dolor sit amet"})
+}
+
+func (s *yamlSuite) Test_YamlValidation(c *C) {
+	person := RequiredName{}
+	req := newRequest(`POST`, ``, `{}`, "text/yaml")
+	err := yamlBinder.Bind(&person, req)
+
+	c.Assert(err, DeepEquals, Errors{{FieldNames: []string{"Name"}, Classification: RequiredError, Message: "Required"}})
+}
+
+func (s *yamlSuite) Test_YamlMalformed(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `{"title":`, "application/x-yaml")
+	err := yamlBinder.Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorDeserialization)
+}
+
+func (s *yamlSuite) Test_YamlWithoutUnmarshal(c *C) {
+	post := Post{}
+	req := newRequest(`POST`, ``, `title: Glorious Post Title`, "application/x-yaml")
+	err := Bind(&post, req)
+
+	c.Assert(err, DeepEquals, ErrorUnsupportedContentType)
+}